@@ -0,0 +1,241 @@
+package timkit
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrUnknownZoneAlias = errors.New("timkit: unknown time zone alias")
+
+// zoneAbbreviations are the candidates of the common abbreviations , the most used first
+var zoneAbbreviations = map[string][]string{
+	"UTC":  {"UTC"},
+	"GMT":  {"UTC"},
+	"Z":    {"UTC"},
+	"EST":  {"America/New_York"},
+	"EDT":  {"America/New_York"},
+	"CST":  {"America/Chicago", "Asia/Shanghai", "America/Havana"},
+	"CDT":  {"America/Chicago", "America/Havana"},
+	"MST":  {"America/Denver", "America/Phoenix"},
+	"MDT":  {"America/Denver"},
+	"PST":  {"America/Los_Angeles", "Asia/Manila"},
+	"PDT":  {"America/Los_Angeles"},
+	"AKST": {"America/Anchorage"},
+	"AKDT": {"America/Anchorage"},
+	"HST":  {"Pacific/Honolulu"},
+	"AST":  {"America/Halifax", "Asia/Riyadh"},
+	"ADT":  {"America/Halifax"},
+	"NST":  {"America/St_Johns"},
+	"NDT":  {"America/St_Johns"},
+	"BRT":  {"America/Sao_Paulo"},
+	"ART":  {"America/Argentina/Buenos_Aires"},
+	"WET":  {"Europe/Lisbon"},
+	"WEST": {"Europe/Lisbon"},
+	"BST":  {"Europe/London", "Asia/Dhaka"},
+	"IST":  {"Asia/Kolkata", "Asia/Jerusalem", "Europe/Dublin"},
+	"CET":  {"Europe/Berlin"},
+	"CEST": {"Europe/Berlin"},
+	"EET":  {"Europe/Athens"},
+	"EEST": {"Europe/Athens"},
+	"MSK":  {"Europe/Moscow"},
+	"SAST": {"Africa/Johannesburg"},
+	"WAT":  {"Africa/Lagos"},
+	"CAT":  {"Africa/Maputo"},
+	"EAT":  {"Africa/Nairobi"},
+	"GST":  {"Asia/Dubai"},
+	"PKT":  {"Asia/Karachi"},
+	"ICT":  {"Asia/Bangkok"},
+	"WIB":  {"Asia/Jakarta"},
+	"HKT":  {"Asia/Hong_Kong"},
+	"SGT":  {"Asia/Singapore"},
+	"PHT":  {"Asia/Manila"},
+	"JST":  {"Asia/Tokyo"},
+	"KST":  {"Asia/Seoul"},
+	"AWST": {"Australia/Perth"},
+	"ACST": {"Australia/Adelaide"},
+	"ACDT": {"Australia/Adelaide"},
+	"AEST": {"Australia/Sydney"},
+	"AEDT": {"Australia/Sydney"},
+	"NZST": {"Pacific/Auckland"},
+	"NZDT": {"Pacific/Auckland"},
+}
+
+// zoneCities are the cities which are not the city of an IANA name , the others are
+// found from the IANA names of the tables , the canonical names first
+var zoneCities = map[string]string{
+	"beijing":        "Asia/Shanghai",
+	"shenzhen":       "Asia/Shanghai",
+	"guangzhou":      "Asia/Shanghai",
+	"hangzhou":       "Asia/Shanghai",
+	"mumbai":         "Asia/Kolkata",
+	"new delhi":      "Asia/Kolkata",
+	"delhi":          "Asia/Kolkata",
+	"bangalore":      "Asia/Kolkata",
+	"osaka":          "Asia/Tokyo",
+	"san francisco":  "America/Los_Angeles",
+	"seattle":        "America/Los_Angeles",
+	"washington":     "America/New_York",
+	"boston":         "America/New_York",
+	"miami":          "America/New_York",
+	"atlanta":        "America/New_York",
+	"dallas":         "America/Chicago",
+	"houston":        "America/Chicago",
+	"montreal":       "America/Toronto",
+	"frankfurt":      "Europe/Berlin",
+	"munich":         "Europe/Berlin",
+	"milan":          "Europe/Rome",
+	"barcelona":      "Europe/Madrid",
+	"geneva":         "Europe/Zurich",
+	"st petersburg":  "Europe/Moscow",
+	"abu dhabi":      "Asia/Dubai",
+	"tel aviv":       "Asia/Jerusalem",
+	"rio de janeiro": "America/Sao_Paulo",
+	"hanoi":          "Asia/Bangkok",
+	"ho chi minh":    "Asia/Ho_Chi_Minh",
+	"saigon":         "Asia/Ho_Chi_Minh",
+	"canberra":       "Australia/Sydney",
+	"wellington":     "Pacific/Auckland",
+}
+
+var (
+	aliasIndexOnce sync.Once
+	// aliasIndex map the normalized Windows IDs and cities to IANA names
+	aliasIndex map[string]string
+	// reverseWindows map the IANA names to the Windows IDs
+	reverseWindows map[string]string
+)
+
+func buildAliasIndex() {
+	aliasIndex = make(map[string]string)
+	reverseWindows = make(map[string]string, len(windowsZones)+len(windowsTerritories))
+	city := func(iana string) {
+		if i := strings.LastIndex(iana, "/"); i >= 0 && !strings.HasPrefix(iana, "Etc/") {
+			if key := normalizeAlias(iana[i+1:]); aliasIndex[key] == "" {
+				aliasIndex[key] = iana
+			}
+		}
+	}
+	for id, iana := range windowsZones {
+		aliasIndex[normalizeAlias(id)] = iana
+		reverseWindows[iana] = id
+		city(iana)
+	}
+	for _, candidates := range zoneAbbreviations {
+		for _, iana := range candidates {
+			city(iana)
+		}
+	}
+	for iana, id := range windowsTerritories {
+		reverseWindows[iana] = id
+		city(iana)
+	}
+	for name, iana := range zoneCities {
+		aliasIndex[name] = iana
+	}
+}
+
+// normalizeAlias lower the case and replace the underscores , e.g. "New_York" is "new york"
+func normalizeAlias(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(strings.Replace(name, "_", " ", -1)), " "))
+}
+
+// ZoneResolver resolve the names used for time zones to IANA names : Windows zone IDs
+// such as "China Standard Time" , abbreviations such as "CST" and cities such as "Beijing" .
+// It is a LocationProvider , so it can be set as the provider of the package
+type ZoneResolver struct {
+	// Preferences are the IANA names chosen first among the candidates of an ambiguous abbreviation
+	Preferences []string
+	source      LocationProvider
+	lock        sync.RWMutex
+	aliases     map[string]string
+}
+
+// NewZoneResolver return a resolver loading the locations from the source , the cached system
+// provider if nil . The preferences decide the ambiguous abbreviations , e.g. "Asia/Shanghai"
+// for "CST" which is America/Chicago otherwise
+func NewZoneResolver(source LocationProvider, preferences ...string) *ZoneResolver {
+	if source == nil {
+		source = NewCachedLocationProvider(SystemLocationProvider)
+	}
+	return &ZoneResolver{Preferences: preferences, source: source, aliases: make(map[string]string)}
+}
+
+// AddAlias add an alias of an IANA name , which win over the built-in aliases
+func (r *ZoneResolver) AddAlias(alias, iana string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.aliases[normalizeAlias(alias)] = iana
+}
+
+// Resolve return the IANA name of an alias . The names containing a slash and unknown to
+// the aliases are returned as they are , as IANA names
+func (r *ZoneResolver) Resolve(name string) (string, error) {
+	key := normalizeAlias(name)
+	r.lock.RLock()
+	iana, ok := r.aliases[key]
+	r.lock.RUnlock()
+	if ok {
+		return iana, nil
+	}
+
+	if candidates := r.Candidates(name); len(candidates) > 0 {
+		for _, p := range r.Preferences {
+			for _, c := range candidates {
+				if c == p {
+					return c, nil
+				}
+			}
+		}
+		return candidates[0], nil
+	}
+
+	aliasIndexOnce.Do(buildAliasIndex)
+	if iana, ok := aliasIndex[key]; ok {
+		return iana, nil
+	}
+	if strings.Contains(name, "/") {
+		return strings.TrimSpace(name), nil
+	}
+	return "", ErrUnknownZoneAlias
+}
+
+// Candidates return the IANA names of an abbreviation , the most used first
+func (r *ZoneResolver) Candidates(abbreviation string) []string {
+	candidates := zoneAbbreviations[strings.ToUpper(strings.TrimSpace(abbreviation))]
+	return append([]string(nil), candidates...)
+}
+
+// Location implements the LocationProvider interface , the name is resolved then loaded
+// from the source . A name which is not an alias is loaded as it is
+func (r *ZoneResolver) Location(name string) (*time.Location, error) {
+	if l, ok := builtinLocation(name); ok {
+		return l, nil
+	}
+	if iana, err := r.Resolve(name); err == nil {
+		return r.source.Location(iana)
+	}
+	return r.source.Location(name)
+}
+
+var defaultZoneResolver = NewZoneResolver(SystemLocationProvider)
+
+// ResolveZone return the IANA name of a Windows zone ID , an abbreviation or a city ,
+// the ambiguous abbreviations are resolved to their most used zone
+func ResolveZone(name string) (string, error) {
+	return defaultZoneResolver.Resolve(name)
+}
+
+// WindowsZone return the Windows zone ID of an IANA name , e.g. "Pacific Standard Time"
+// for "America/Los_Angeles"
+func WindowsZone(iana string) (string, error) {
+	aliasIndexOnce.Do(buildAliasIndex)
+	if id, ok := reverseWindows[iana]; ok {
+		return id, nil
+	}
+	if iana == "" || iana == "UTC" {
+		return "UTC", nil
+	}
+	return "", ErrUnknownZoneAlias
+}
@@ -0,0 +1,89 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestZoneResolver_Resolve(t *testing.T) {
+	r := NewZoneResolver(nil, "Asia/Shanghai", "Asia/Jerusalem")
+	r.AddAlias("HQ", "Europe/Paris")
+	tests := map[string]string{
+		"China Standard Time":            "Asia/Shanghai",
+		"pacific standard time":          "America/Los_Angeles",
+		"Pacific Standard Time (Mexico)": "America/Tijuana",
+		"CST":                            "Asia/Shanghai",
+		"cdt":                            "America/Chicago",
+		"IST":                            "Asia/Jerusalem",
+		"PST":                            "America/Los_Angeles",
+		"Beijing":                        "Asia/Shanghai",
+		"new york":                       "America/New_York",
+		"Sao_Paulo":                      "America/Sao_Paulo",
+		"Buenos Aires":                   "America/Argentina/Buenos_Aires",
+		"Hong Kong":                      "Asia/Hong_Kong",
+		"hq":                             "Europe/Paris",
+		"Europe/Amsterdam":               "Europe/Amsterdam",
+	}
+	for name, expected := range tests {
+		if iana, err := r.Resolve(name); err != nil || iana != expected {
+			t.Errorf("Resolve(%q) = %+v %v ,expected %+v", name, iana, err, expected)
+		}
+	}
+	if _, err := r.Resolve("Atlantis"); err != ErrUnknownZoneAlias {
+		t.Errorf("Resolve error = %v ,expected %v", err, ErrUnknownZoneAlias)
+	}
+	if iana, _ := ResolveZone("CST"); iana != "America/Chicago" {
+		t.Errorf("ResolveZone = %+v ,expected %+v", iana, "America/Chicago")
+	}
+	if c := r.Candidates("BST"); len(c) != 2 || c[0] != "Europe/London" {
+		t.Errorf("Candidates = %+v ,expected %+v", c, []string{"Europe/London", "Asia/Dhaka"})
+	}
+}
+
+func TestZoneResolver_Location(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Kyiv"); err != nil {
+		t.Skip(err)
+	}
+	SetLocationProvider(NewZoneResolver(nil, "Asia/Shanghai"))
+	defer SetLocationProvider(nil)
+
+	tk, err := Parse(DefaultFormat, "2021-01-02 15:04:05", "China Standard Time")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Location().String() != "Asia/Shanghai" || tk.Unix() != 1609571045 {
+		t.Errorf("Parse = %+v ,expected 2021-01-02 15:04:05 in Asia/Shanghai", tk.Time)
+	}
+	if _, err := LoadLocation("Japan"); err != nil {
+		t.Errorf("LoadLocation error %v ,expected the IANA link", err)
+	}
+	for id, iana := range windowsZones {
+		if _, err := LoadLocation(id); err != nil {
+			t.Errorf("LoadLocation(%q) = %v ,expected %s", id, err, iana)
+		}
+	}
+}
+
+func TestWindowsZone(t *testing.T) {
+	tests := map[string]string{
+		"America/Los_Angeles": "Pacific Standard Time",
+		"Asia/Shanghai":       "China Standard Time",
+		"Asia/Hong_Kong":      "China Standard Time",
+		"Asia/Calcutta":       "India Standard Time",
+		"Europe/Amsterdam":    "W. Europe Standard Time",
+		"UTC":                 "UTC",
+	}
+	for iana, expected := range tests {
+		if id, err := WindowsZone(iana); err != nil || id != expected {
+			t.Errorf("WindowsZone(%q) = %+v %v ,expected %+v", iana, id, err, expected)
+		}
+	}
+	for id, iana := range windowsZones {
+		if back, _ := WindowsZone(iana); back != id && iana != "UTC" {
+			t.Errorf("WindowsZone(%q) = %+v ,expected %+v", iana, back, id)
+		}
+	}
+	if _, err := WindowsZone("Mars/Olympus_Mons"); err != ErrUnknownZoneAlias {
+		t.Errorf("WindowsZone error = %v ,expected %v", err, ErrUnknownZoneAlias)
+	}
+}
@@ -0,0 +1,144 @@
+package timkit
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// binaryVersion is the first byte of the binary encoding
+const binaryVersion byte = 1
+
+var ErrInvalidBinary = errors.New("timkit: invalid binary data")
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface .
+// It keeps the nanoseconds , the location name and the settings of the instance
+func (tk *TimeKit) MarshalBinary() ([]byte, error) {
+	c := tk.Copy()
+	t, err := c.Time.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	data := []byte{binaryVersion, byte(len(t))}
+	data = append(data, t...)
+	data = appendString(data, c.Location().String())
+	data = appendString(data, c.format)
+	data = append(data, byte(c.weekStartAt), byte(c.weekEndAt), byte(len(c.weekendDays)))
+	for _, d := range c.weekendDays {
+		data = append(data, byte(d))
+	}
+	data = appendUvarint(data, uint64(c.wireFormat))
+	data = appendUvarint(data, uint64(c.storageMode))
+	data = appendUvarint(data, uint64(c.wallTimePolicy))
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface .
+// When the location can not be loaded by name the time keeps its offset in a fixed zone
+func (tk *TimeKit) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	if r.byte() != binaryVersion {
+		return ErrInvalidBinary
+	}
+	var t time.Time
+	if err := t.UnmarshalBinary(r.bytes(int(r.byte()))); err != nil {
+		return err
+	}
+	name := r.string()
+	format := r.string()
+	weekStartAt, weekEndAt := time.Weekday(r.byte()), time.Weekday(r.byte())
+	weekendDays := make([]time.Weekday, r.byte())
+	for i := range weekendDays {
+		weekendDays[i] = time.Weekday(r.byte())
+	}
+	wireFormat := WireFormat(r.uvarint())
+	storageMode := StorageMode(r.uvarint())
+	wallTimePolicy := WallTimePolicy(r.uvarint())
+	if r.err != nil {
+		return r.err
+	}
+
+	switch name {
+	case "", "UTC":
+	case "Local":
+		t = t.In(time.Local)
+	default:
+		if l, err := loadLocation(name); err == nil {
+			t = t.In(l)
+		}
+	}
+
+	tk.lock.Lock()
+	defer tk.lock.Unlock()
+	tk.Time = t
+	tk.format = format
+	tk.weekStartAt = weekStartAt
+	tk.weekEndAt = weekEndAt
+	tk.weekendDays = weekendDays
+	tk.wireFormat = wireFormat
+	tk.storageMode = storageMode
+	tk.wallTimePolicy = wallTimePolicy
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface
+func (tk *TimeKit) GobEncode() ([]byte, error) {
+	return tk.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface
+func (tk *TimeKit) GobDecode(data []byte) error {
+	return tk.UnmarshalBinary(data)
+}
+
+func appendUvarint(data []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(data, buf[:n]...)
+}
+
+func appendString(data []byte, s string) []byte {
+	data = appendUvarint(data, uint64(len(s)))
+	return append(data, s...)
+}
+
+// binaryReader read the binary encoding , the first error is kept in err
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) bytes(n int) []byte {
+	if r.err != nil || n < 0 || n > len(r.data) {
+		r.err = ErrInvalidBinary
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *binaryReader) byte() byte {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = ErrInvalidBinary
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *binaryReader) string() string {
+	return string(r.bytes(int(r.uvarint())))
+}
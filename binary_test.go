@@ -0,0 +1,88 @@
+package timkit
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+	"time"
+)
+
+func TestTimeKit_MarshalBinary(t *testing.T) {
+	l, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+	tk := NewOptions(
+		OptionSetTime(time.Date(2021, 1, 2, 15, 4, 5, 123456789, l)),
+		OptionSetFormat(time.RFC3339Nano),
+		OptionSetWeekStartAt(time.Sunday),
+		OptionSetWeekEndAt(time.Saturday),
+		OptionSetWireFormat(WireUnixMilli),
+		OptionSetWallTimePolicy(WallTimeLater),
+	)
+
+	data, err := tk.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded TimeKit
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(tk.Time) || decoded.Location().String() != "Asia/Shanghai" {
+		t.Errorf("UnmarshalBinary = %+v ,expected %+v", decoded.Time, tk.Time)
+	}
+	if decoded.String() != tk.String() || decoded.WireFormat() != WireUnixMilli {
+		t.Errorf("UnmarshalBinary lost the format : %+v ,expected %+v", decoded.String(), tk.String())
+	}
+	if decoded.WallTimePolicy() != WallTimeLater {
+		t.Errorf("UnmarshalBinary = %+v ,expected %+v", decoded.WallTimePolicy(), WallTimeLater)
+	}
+	if s := decoded.Copy().StartOfWeek().DateString(); s != "2020-12-27" {
+		t.Errorf("StartOfWeek = %+v ,expected %+v", s, "2020-12-27")
+	}
+	if s := decoded.Copy().EndOfWeek().DateString(); s != "2021-01-02" {
+		t.Errorf("EndOfWeek = %+v ,expected %+v", s, "2021-01-02")
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-3]); err != ErrInvalidBinary {
+		t.Errorf("UnmarshalBinary = %+v ,expected %+v", err, ErrInvalidBinary)
+	}
+
+	// the wall time policy is always encoded
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err != ErrInvalidBinary {
+		t.Errorf("UnmarshalBinary = %+v ,expected %+v", err, ErrInvalidBinary)
+	}
+}
+
+func TestTimeKit_Gob(t *testing.T) {
+	type payload struct {
+		At *TimeKit
+	}
+	tk := NewTimeKit(time.Date(2021, 1, 2, 15, 4, 5, 6, time.UTC))
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(payload{tk}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded payload
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.At.Equal(tk.Time) || !decoded.At.IsWeekend() {
+		t.Errorf("GobDecode = %+v ,expected %+v", decoded.At.Time, tk.Time)
+	}
+}
+
+func TestTimeKit_Copy(t *testing.T) {
+	tk := NewOptions(OptionSetTime(time.Date(2021, 1, 2, 15, 4, 5, 6, time.UTC)), OptionSetFormat(time.RFC3339Nano))
+	tk.SetWallTimePolicy(WallTimeError)
+	c := tk.Copy()
+	if c.String() != "2021-01-02T15:04:05.000000006Z" || c.WallTimePolicy() != WallTimeError {
+		t.Errorf("Copy = %+v %+v ,expected %+v", c, c.WallTimePolicy(), "2021-01-02T15:04:05.000000006Z")
+	}
+	var dst TimeKit
+	if dst.set(tk); dst.String() != c.String() || dst.WallTimePolicy() != WallTimeError {
+		t.Errorf("set = %+v %+v ,expected %+v", dst.String(), dst.WallTimePolicy(), c.String())
+	}
+}
@@ -0,0 +1,540 @@
+package timkit
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Frequency is how often a recurring event repeats
+type Frequency int
+
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+// DefaultHorizon is how far ConflictsWith expands an event repeating forever
+const DefaultHorizon = daysInNormalYear * hoursPerDay * time.Hour
+
+var (
+	ErrInvalidEvent   = errors.New("timkit: event needs an id , a start and an end after the start")
+	ErrDuplicateEvent = errors.New("timkit: event id already in the calendar")
+)
+
+// Recurrence describe how an event repeats , after the RRULE of RFC 5545 .
+// Occurrences keep the wall clock time of the first one in the event location ,
+// dates which do not exist in a month or a year are skipped
+type Recurrence struct {
+	Frequency Frequency
+	// Interval is the number of frequency units between two repetitions , 0 means 1
+	Interval int
+	// Count limit the number of occurrences , 0 for no limit
+	Count int
+	// Until is the last instant an occurrence may start at , nil for no limit
+	Until *TimeKit
+	// ByWeekday repeat a weekly event on the given days instead of the day of Start
+	ByWeekday []time.Weekday
+}
+
+// Event is an entry of a Calendar
+type Event struct {
+	ID    string
+	Start *TimeKit
+	End   *TimeKit
+	// Location is the zone the recurrence is expanded in , empty for the location of Start
+	Location   string
+	Recurrence *Recurrence
+}
+
+// Occurrence is a single occurrence of an event
+type Occurrence struct {
+	Event *Event
+	Period
+}
+
+// calendarEvent is an event prepared for the expansion of its occurrences
+type calendarEvent struct {
+	event    *Event
+	location *time.Location
+	start    time.Time
+	end      time.Time
+	wallSpan time.Duration
+
+	frequency Frequency
+	interval  int
+	until     time.Time
+	weekStart time.Time
+	offsets   []int
+	// fixed hold the occurrences of an event with a count
+	fixed []span
+	// last is an upper bound of the end of the last occurrence , zero if it repeats forever
+	last time.Time
+}
+
+// wallClock return the wall clock of t as a time in UTC
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// civilDays return the number of calendar days from the date of a to the date of b
+func civilDays(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int((db.Unix() - da.Unix()) / (hoursPerDay * minutesPerHour * secondsPerMinute))
+}
+
+func newCalendarEvent(e *Event) (*calendarEvent, error) {
+	if e == nil || e.ID == "" || e.Start == nil || e.End == nil || e.End.Before(e.Start.Time) {
+		return nil, ErrInvalidEvent
+	}
+	l := e.Start.Location()
+	if e.Location != "" {
+		var err error
+		if l, err = loadLocation(e.Location); err != nil {
+			return nil, err
+		}
+	}
+	ce := &calendarEvent{
+		event:    e,
+		location: l,
+		start:    e.Start.In(l),
+		end:      e.End.In(l),
+		last:     e.End.Time,
+	}
+	r := e.Recurrence
+	if r == nil {
+		return ce, nil
+	}
+	if r.Frequency < Daily || r.Frequency > Yearly || r.Interval < 0 || r.Count < 0 {
+		return nil, ErrInvalidEvent
+	}
+	ce.wallSpan = wallClock(ce.end).Sub(wallClock(ce.start))
+	ce.frequency = r.Frequency
+	ce.interval = r.Interval
+	if ce.interval == 0 {
+		ce.interval = 1
+	}
+	if r.Frequency == Weekly && len(r.ByWeekday) > 0 {
+		ws := e.Start.Copy()
+		ws.SetTime(ce.start)
+		ce.weekStart = ws.StartOfWeek().Time
+		seen := make(map[int]bool)
+		for _, wd := range r.ByWeekday {
+			off := (int(wd) - int(ce.weekStart.Weekday()) + daysPerWeek) % daysPerWeek
+			if !seen[off] {
+				seen[off] = true
+				ce.offsets = append(ce.offsets, off)
+			}
+		}
+		sort.Ints(ce.offsets)
+	}
+	if r.Until != nil {
+		ce.until = r.Until.Time
+	}
+
+	ce.last = time.Time{}
+	switch {
+	case r.Count > 0:
+		ce.fixed = ce.expand(ce.start, time.Time{}, r.Count)
+		if len(ce.fixed) > 0 {
+			ce.last = ce.fixed[len(ce.fixed)-1].end
+		}
+	case !ce.until.IsZero():
+		ce.last = ce.until.Add(ce.wallSpan + hoursPerDay*time.Hour)
+	}
+	return ce, nil
+}
+
+// repeating whether the event has more than one occurrence
+func (ce *calendarEvent) repeating() bool {
+	return ce.frequency != 0
+}
+
+// occurrenceEnd return the end of the occurrence starting at start
+func (ce *calendarEvent) occurrenceEnd(start time.Time) time.Time {
+	w := wallClock(start).Add(ce.wallSpan)
+	return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), ce.location)
+}
+
+// at return the local time of the first occurrence moved by the given calendar offsets
+func (ce *calendarEvent) at(base time.Time, years, months, days int) time.Time {
+	s := ce.start
+	return time.Date(base.Year()+years, base.Month()+time.Month(months), base.Day()+days,
+		s.Hour(), s.Minute(), s.Second(), s.Nanosecond(), ce.location)
+}
+
+// validDate whether the date moved by the given offsets exists , e.g. there is no 31 in April
+func validDate(base time.Time, years, months int) bool {
+	d := time.Date(base.Year()+years, base.Month()+time.Month(months), base.Day(), 12, 0, 0, 0, time.UTC)
+	return d.Day() == base.Day()
+}
+
+// period return the nominal start of the k-th repetition and its occurrences
+func (ce *calendarEvent) period(k int) (time.Time, []time.Time) {
+	n := k * ce.interval
+	var t time.Time
+	switch ce.frequency {
+	case Daily:
+		t = ce.at(ce.start, 0, 0, n)
+	case Weekly:
+		if len(ce.offsets) > 0 {
+			nominal := ce.at(ce.weekStart, 0, 0, n*daysPerWeek)
+			var starts []time.Time
+			for _, off := range ce.offsets {
+				t := ce.at(ce.weekStart, 0, 0, n*daysPerWeek+off)
+				if !t.Before(ce.start) {
+					starts = append(starts, t)
+				}
+			}
+			return nominal, starts
+		}
+		t = ce.at(ce.start, 0, 0, n*daysPerWeek)
+	case Monthly:
+		t = ce.at(ce.start, 0, n, 0)
+		if !validDate(ce.start, 0, n) {
+			return t, nil
+		}
+	case Yearly:
+		t = ce.at(ce.start, n, 0, 0)
+		if !validDate(ce.start, n, 0) {
+			return t, nil
+		}
+	}
+	return t, []time.Time{t}
+}
+
+// firstPeriod return a repetition index starting before from
+func (ce *calendarEvent) firstPeriod(from time.Time) int {
+	from = from.In(ce.location)
+	var k int
+	switch ce.frequency {
+	case Daily:
+		k = civilDays(ce.start, from)
+	case Weekly:
+		k = civilDays(ce.start, from) / daysPerWeek
+	case Monthly:
+		k = (from.Year()-ce.start.Year())*monthsPerYear + int(from.Month()) - int(ce.start.Month())
+	case Yearly:
+		k = from.Year() - ce.start.Year()
+	}
+	k = k/ce.interval - 1
+	if k < 0 {
+		k = 0
+	}
+	return k
+}
+
+// expand return up to limit occurrences ending after from and starting before to .
+// A zero to or limit means no bound
+func (ce *calendarEvent) expand(from, to time.Time, limit int) []span {
+	var out []span
+	// the occurrences are skipped by whole repetitions up to one day before from
+	k := ce.firstPeriod(from.Add(-ce.wallSpan - hoursPerDay*time.Hour))
+	if limit > 0 {
+		k = 0
+	}
+	count := 0
+	for ; ; k++ {
+		nominal, starts := ce.period(k)
+		if !to.IsZero() && !nominal.Before(to) {
+			return out
+		}
+		for _, s := range starts {
+			if !ce.until.IsZero() && s.After(ce.until) {
+				return out
+			}
+			if !to.IsZero() && !s.Before(to) {
+				return out
+			}
+			count++
+			if e := ce.occurrenceEnd(s); e.After(from) {
+				out = append(out, span{s, e})
+			}
+			if limit > 0 && count >= limit {
+				return out
+			}
+		}
+	}
+}
+
+// between return the occurrences of the event overlapping [from, to)
+func (ce *calendarEvent) between(from, to time.Time) []span {
+	if !ce.repeating() {
+		if ce.start.Before(to) && ce.end.After(from) {
+			return []span{{ce.start, ce.end}}
+		}
+		return nil
+	}
+	if !ce.start.Before(to) || (!ce.last.IsZero() && !ce.last.After(from)) {
+		return nil
+	}
+	if ce.fixed == nil {
+		return ce.expand(from, to, 0)
+	}
+	lo := sort.Search(len(ce.fixed), func(i int) bool {
+		return ce.fixed[i].end.After(from)
+	})
+	hi := sort.Search(len(ce.fixed), func(i int) bool {
+		return !ce.fixed[i].start.Before(to)
+	})
+	if lo >= hi {
+		return nil
+	}
+	return ce.fixed[lo:hi]
+}
+
+// Calendar is an in-memory store of events , it is safe for concurrent use
+type Calendar struct {
+	lock    sync.RWMutex
+	horizon time.Duration
+	events  map[string]*calendarEvent
+	// single hold the events without recurrence sorted by start
+	single    []*calendarEvent
+	maxSingle time.Duration
+	// recurring hold the events with recurrence sorted by start , as an implicit interval tree :
+	// the node of the range [lo, hi) is its middle and recurringEnd hold the latest end of the
+	// events of its range , zero when one of them repeats forever
+	recurring    []*calendarEvent
+	recurringEnd []time.Time
+}
+
+// CalendarOption configure a Calendar
+type CalendarOption func(c *Calendar)
+
+// CalendarOptionSetHorizon set how far ConflictsWith expands an event repeating forever
+func CalendarOptionSetHorizon(d time.Duration) CalendarOption {
+	return func(c *Calendar) {
+		c.horizon = d
+	}
+}
+
+// NewCalendar return a new empty Calendar
+func NewCalendar(opt ...CalendarOption) *Calendar {
+	c := &Calendar{
+		horizon: DefaultHorizon,
+		events:  make(map[string]*calendarEvent),
+	}
+	for _, o := range opt {
+		o(c)
+	}
+	return c
+}
+
+// Add add an event to the calendar , the event must not be modified afterwards
+func (c *Calendar) Add(e *Event) error {
+	ce, err := newCalendarEvent(e)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.events[e.ID]; ok {
+		return ErrDuplicateEvent
+	}
+	c.events[e.ID] = ce
+	if ce.repeating() {
+		c.recurring = insertByStart(c.recurring, ce)
+		c.indexRecurring()
+		return nil
+	}
+	c.single = insertByStart(c.single, ce)
+	if d := ce.end.Sub(ce.start); d > c.maxSingle {
+		c.maxSingle = d
+	}
+	return nil
+}
+
+// Remove remove the event with the given id , it return false if there is none
+func (c *Calendar) Remove(id string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ce, ok := c.events[id]
+	if !ok {
+		return false
+	}
+	delete(c.events, id)
+	list := &c.single
+	if ce.repeating() {
+		list = &c.recurring
+	}
+	for i, v := range *list {
+		if v == ce {
+			*list = append((*list)[:i], (*list)[i+1:]...)
+			break
+		}
+	}
+	if ce.repeating() {
+		c.indexRecurring()
+	}
+	return true
+}
+
+// insertByStart insert ce in the events sorted by start , after the events with the same start
+func insertByStart(events []*calendarEvent, ce *calendarEvent) []*calendarEvent {
+	i := sort.Search(len(events), func(i int) bool {
+		return events[i].start.After(ce.start)
+	})
+	events = append(events, nil)
+	copy(events[i+1:], events[i:])
+	events[i] = ce
+	return events
+}
+
+// indexRecurring compute the latest ends of the interval tree of the recurring events
+func (c *Calendar) indexRecurring() {
+	if cap(c.recurringEnd) < len(c.recurring) {
+		c.recurringEnd = make([]time.Time, len(c.recurring), cap(c.recurring))
+	}
+	c.recurringEnd = c.recurringEnd[:len(c.recurring)]
+	c.indexRange(0, len(c.recurring))
+}
+
+// indexRange set the latest end of the range [lo, hi) , which must not be empty , and its subranges
+func (c *Calendar) indexRange(lo, hi int) time.Time {
+	mid := (lo + hi) / 2
+	end := c.recurring[mid].last
+	if lo < mid {
+		end = laterEnd(end, c.indexRange(lo, mid))
+	}
+	if mid+1 < hi {
+		end = laterEnd(end, c.indexRange(mid+1, hi))
+	}
+	c.recurringEnd[mid] = end
+	return end
+}
+
+// laterEnd return the later of two ends , zero meaning never
+func laterEnd(a, b time.Time) time.Time {
+	if a.IsZero() || b.IsZero() {
+		return time.Time{}
+	}
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// recurringBetween call f for the recurring events of the range [lo, hi) which start before to
+// and may end after from
+func (c *Calendar) recurringBetween(lo, hi int, from, to time.Time, f func(ce *calendarEvent)) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	if end := c.recurringEnd[mid]; !end.IsZero() && !end.After(from) {
+		return
+	}
+	c.recurringBetween(lo, mid, from, to, f)
+	if !c.recurring[mid].start.Before(to) {
+		return
+	}
+	f(c.recurring[mid])
+	c.recurringBetween(mid+1, hi, from, to, f)
+}
+
+// Len return the number of events in the calendar
+func (c *Calendar) Len() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return len(c.events)
+}
+
+// EventsBetween return the occurrences overlapping [a, b) sorted by start
+func (c *Calendar) EventsBetween(a, b *TimeKit) []Occurrence {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.between(a.Time, b.Time)
+}
+
+func (c *Calendar) between(from, to time.Time) []Occurrence {
+	var out []Occurrence
+	add := func(ce *calendarEvent, spans []span) {
+		for _, s := range spans {
+			out = append(out, Occurrence{Event: ce.event, Period: s.period(ce.location)})
+		}
+	}
+
+	lo := sort.Search(len(c.single), func(i int) bool {
+		return c.single[i].start.After(from.Add(-c.maxSingle))
+	})
+	for _, ce := range c.single[lo:] {
+		if !ce.start.Before(to) {
+			break
+		}
+		add(ce, ce.between(from, to))
+	}
+	c.recurringBetween(0, len(c.recurring), from, to, func(ce *calendarEvent) {
+		add(ce, ce.between(from, to))
+	})
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Start.Equal(out[j].Start.Time) {
+			return out[i].Event.ID < out[j].Event.ID
+		}
+		return out[i].Start.Before(out[j].Start.Time)
+	})
+	return out
+}
+
+// ConflictsWith return the occurrences overlapping any occurrence of e .
+// An event repeating forever is only checked up to the horizon of the calendar
+func (c *Calendar) ConflictsWith(e *Event) ([]Occurrence, error) {
+	ce, err := newCalendarEvent(e)
+	if err != nil {
+		return nil, err
+	}
+	to := ce.last
+	if to.IsZero() {
+		to = ce.start.Add(c.horizon)
+	}
+	spans := ce.between(ce.start, to)
+	if len(spans) == 0 {
+		return nil, nil
+	}
+	to = spans[len(spans)-1].end
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	var out []Occurrence
+	for _, o := range c.between(ce.start, to) {
+		if o.Event.ID == e.ID {
+			continue
+		}
+		i := sort.Search(len(spans), func(i int) bool {
+			return spans[i].end.After(o.Start.Time)
+		})
+		if i < len(spans) && spans[i].start.Before(o.End.Time) {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
+
+// Busy return the merged periods of [a, b) covered by at least one occurrence
+func (c *Calendar) Busy(a, b *TimeKit) []Period {
+	c.lock.RLock()
+	occurrences := c.between(a.Time, b.Time)
+	c.lock.RUnlock()
+
+	spans := make([]span, 0, len(occurrences))
+	for _, o := range occurrences {
+		s := span{o.Start.Time, o.End.Time}
+		if s.start.Before(a.Time) {
+			s.start = a.Time
+		}
+		if s.end.After(b.Time) {
+			s.end = b.Time
+		}
+		spans = append(spans, s)
+	}
+	periods := make([]Period, 0, len(spans))
+	for _, s := range mergeSpans(spans) {
+		periods = append(periods, s.period(a.Location()))
+	}
+	return periods
+}
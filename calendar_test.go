@@ -0,0 +1,220 @@
+package timkit
+
+import (
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func calendarTime(t *testing.T, value string) *TimeKit {
+	tk, err := Parse(DefaultFormat, value, "America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tk
+}
+
+func occurrenceStarts(occurrences []Occurrence) []string {
+	starts := make([]string, 0, len(occurrences))
+	for _, o := range occurrences {
+		starts = append(starts, o.Event.ID+" "+o.Start.String())
+	}
+	return starts
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCalendar_EventsBetween(t *testing.T) {
+	c := NewCalendar()
+	c.Add(&Event{
+		ID:    "standup",
+		Start: calendarTime(t, "2021-03-08 09:00:00"),
+		End:   calendarTime(t, "2021-03-08 09:15:00"),
+		Recurrence: &Recurrence{
+			Frequency: Weekly,
+			ByWeekday: []time.Weekday{time.Monday, time.Friday},
+		},
+	})
+	c.Add(&Event{
+		ID:    "review",
+		Start: calendarTime(t, "2021-03-12 08:30:00"),
+		End:   calendarTime(t, "2021-03-12 10:00:00"),
+	})
+
+	got := occurrenceStarts(c.EventsBetween(calendarTime(t, "2021-03-09 00:00:00"), calendarTime(t, "2021-03-16 00:00:00")))
+	// the occurrences keep 09:00 local time across the DST change of 2021-03-14
+	expected := []string{
+		"review 2021-03-12 08:30:00",
+		"standup 2021-03-12 09:00:00",
+		"standup 2021-03-15 09:00:00",
+	}
+	if !equalStrings(got, expected) {
+		t.Errorf("EventsBetween = %+v ,expected %+v", got, expected)
+	}
+}
+
+func TestCalendar_Recurrence(t *testing.T) {
+	c := NewCalendar()
+	c.Add(&Event{
+		ID:         "rent",
+		Start:      calendarTime(t, "2021-01-31 10:00:00"),
+		End:        calendarTime(t, "2021-01-31 11:00:00"),
+		Recurrence: &Recurrence{Frequency: Monthly, Count: 3},
+	})
+
+	got := occurrenceStarts(c.EventsBetween(calendarTime(t, "2021-01-01 00:00:00"), calendarTime(t, "2022-01-01 00:00:00")))
+	// months without a 31st are skipped
+	expected := []string{
+		"rent 2021-01-31 10:00:00",
+		"rent 2021-03-31 10:00:00",
+		"rent 2021-05-31 10:00:00",
+	}
+	if !equalStrings(got, expected) {
+		t.Errorf("EventsBetween = %+v ,expected %+v", got, expected)
+	}
+}
+
+func TestCalendar_RecurringIndex(t *testing.T) {
+	c := NewCalendar()
+	start := time.Date(2021, 1, 4, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 60; i++ {
+		s := start.AddDate(0, 0, i)
+		r := &Recurrence{Frequency: Daily, Count: i%5 + 1}
+		switch i % 3 {
+		case 1:
+			r = &Recurrence{Frequency: Weekly, Until: NewTimeKit(s.AddDate(0, 0, 7*(i%4)))}
+		case 2:
+			r = &Recurrence{Frequency: Monthly}
+		}
+		c.Add(&Event{ID: "event-" + strconv.Itoa(i), Start: NewTimeKit(s), End: NewTimeKit(s.Add(time.Hour)), Recurrence: r})
+	}
+	for i := 0; i < 60; i += 7 {
+		c.Remove("event-" + strconv.Itoa(i))
+	}
+
+	for d := 0; d < 120; d += 3 {
+		from := start.AddDate(0, 0, d)
+		to := from.AddDate(0, 0, 2)
+		var expected []string
+		for _, ce := range c.recurring {
+			for _, s := range ce.between(from, to) {
+				expected = append(expected, ce.event.ID+" "+s.start.String())
+			}
+		}
+		var got []string
+		for _, o := range c.EventsBetween(NewTimeKit(from), NewTimeKit(to)) {
+			got = append(got, o.Event.ID+" "+o.Start.Time.String())
+		}
+		sort.Strings(expected)
+		sort.Strings(got)
+		if !equalStrings(got, expected) {
+			t.Errorf("EventsBetween(%s) = %+v ,expected %+v", from, got, expected)
+		}
+	}
+}
+
+func TestCalendar_ConflictsWith(t *testing.T) {
+	c := NewCalendar()
+	c.Add(&Event{
+		ID:         "daily",
+		Start:      calendarTime(t, "2021-03-01 14:00:00"),
+		End:        calendarTime(t, "2021-03-01 15:00:00"),
+		Recurrence: &Recurrence{Frequency: Daily, Interval: 2},
+	})
+	conflicts, err := c.ConflictsWith(&Event{
+		ID:         "new",
+		Start:      calendarTime(t, "2021-03-02 14:30:00"),
+		End:        calendarTime(t, "2021-03-02 15:30:00"),
+		Recurrence: &Recurrence{Frequency: Daily, Count: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"daily 2021-03-03 14:00:00"}
+	if got := occurrenceStarts(conflicts); !equalStrings(got, expected) {
+		t.Errorf("ConflictsWith = %+v ,expected %+v", got, expected)
+	}
+}
+
+func TestCalendar_Busy(t *testing.T) {
+	c := NewCalendar()
+	c.Add(&Event{ID: "a", Start: calendarTime(t, "2021-03-01 09:00:00"), End: calendarTime(t, "2021-03-01 10:00:00")})
+	c.Add(&Event{ID: "b", Start: calendarTime(t, "2021-03-01 09:30:00"), End: calendarTime(t, "2021-03-01 11:00:00")})
+	c.Add(&Event{ID: "c", Start: calendarTime(t, "2021-03-01 13:00:00"), End: calendarTime(t, "2021-03-01 14:00:00")})
+
+	busy := c.Busy(calendarTime(t, "2021-03-01 09:45:00"), calendarTime(t, "2021-03-01 18:00:00"))
+	var got []string
+	for _, p := range busy {
+		got = append(got, p.String())
+	}
+	expected := []string{
+		"2021-03-01 09:45:00 - 2021-03-01 11:00:00",
+		"2021-03-01 13:00:00 - 2021-03-01 14:00:00",
+	}
+	if !equalStrings(got, expected) {
+		t.Errorf("Busy = %+v ,expected %+v", got, expected)
+	}
+}
+
+func BenchmarkCalendar_EventsBetween(b *testing.B) {
+	c := NewCalendar()
+	start := time.Date(2000, 1, 3, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 5000; i++ {
+		s := start.Add(time.Duration(i) * time.Hour)
+		c.Add(&Event{
+			ID:         "event-" + strconv.Itoa(i),
+			Start:      NewTimeKit(s),
+			End:        NewTimeKit(s.Add(30 * time.Minute)),
+			Recurrence: &Recurrence{Frequency: Frequency(i%4 + 1)},
+		})
+	}
+	from := NewTimeKit(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC))
+	to := NewTimeKit(time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC))
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.EventsBetween(from, to)
+	}
+}
+
+func BenchmarkCalendar_EventsBetween_Bounded(b *testing.B) {
+	c := NewCalendar()
+	start := time.Date(2000, 1, 3, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 5000; i++ {
+		// each event repeats for about 2 weeks , a new one starts every 2 days
+		s := start.AddDate(0, 0, 2*i)
+		r := &Recurrence{Frequency: Daily, Count: 14}
+		if i%2 == 1 {
+			r = &Recurrence{Frequency: Weekly, Until: NewTimeKit(s.AddDate(0, 0, 14))}
+		}
+		c.Add(&Event{
+			ID:         "event-" + strconv.Itoa(i),
+			Start:      NewTimeKit(s),
+			End:        NewTimeKit(s.Add(30 * time.Minute)),
+			Recurrence: r,
+		})
+	}
+	from := NewTimeKit(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC))
+	to := NewTimeKit(time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC))
+	if len(c.EventsBetween(from, to)) == 0 {
+		b.Fatal("EventsBetween ,expected occurrences")
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.EventsBetween(from, to)
+	}
+}
@@ -0,0 +1,174 @@
+package timkit
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of the current time and of timers used by the package
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single shot timer created by a Clock
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+var (
+	clockLock sync.RWMutex
+	clock     Clock = systemClock{}
+)
+
+// SetClock replace the clock used by the package .
+// A nil clock restores the system clock
+func SetClock(c Clock) {
+	clockLock.Lock()
+	defer clockLock.Unlock()
+	if c == nil {
+		c = systemClock{}
+	}
+	clock = c
+}
+
+// GetClock return the clock used by the package
+func GetClock() Clock {
+	clockLock.RLock()
+	defer clockLock.RUnlock()
+	return clock
+}
+
+// now return the current time of the package clock
+func now() time.Time {
+	return GetClock().Now()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return &systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (st *systemTimer) C() <-chan time.Time {
+	return st.t.C
+}
+
+func (st *systemTimer) Stop() bool {
+	return st.t.Stop()
+}
+
+// FakeClock is a manually driven Clock for tests .
+// Its time only moves when Advance or Set is called
+type FakeClock struct {
+	lock   sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock return a new FakeClock stopped at the given time
+func NewFakeClock(t time.Time) *FakeClock {
+	c := &FakeClock{now: t}
+	c.cond = sync.NewCond(&c.lock)
+	return c
+}
+
+// Now return the current time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// NewTimer return a timer firing once the clock reaches now + d
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	t := &fakeTimer{
+		clock:    c,
+		c:        make(chan time.Time, 1),
+		deadline: c.now.Add(d),
+	}
+	if d <= 0 {
+		t.c <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	c.cond.Broadcast()
+	return t
+}
+
+// Advance move the clock forward by d and fire the expired timers
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	c.setLocked(c.now.Add(d))
+	c.lock.Unlock()
+}
+
+// Set move the clock to t and fire the expired timers
+func (c *FakeClock) Set(t time.Time) {
+	c.lock.Lock()
+	c.setLocked(t)
+	c.lock.Unlock()
+}
+
+// BlockUntil wait until at least n timers are pending on the clock
+func (c *FakeClock) BlockUntil(n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) setLocked(t time.Time) {
+	c.now = t
+	sort.Slice(c.timers, func(i, j int) bool {
+		return c.timers[i].deadline.Before(c.timers[j].deadline)
+	})
+	pending := c.timers[:0]
+	for _, ft := range c.timers {
+		if ft.deadline.After(t) {
+			pending = append(pending, ft)
+			continue
+		}
+		ft.c <- t
+	}
+	c.timers = pending
+	c.cond.Broadcast()
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (ft *fakeTimer) C() <-chan time.Time {
+	return ft.c
+}
+
+func (ft *fakeTimer) Stop() bool {
+	c := ft.clock
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i, t := range c.timers {
+		if t == ft {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			c.cond.Broadcast()
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock_Advance(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	c := NewFakeClock(start)
+	timer := c.NewTimer(time.Minute)
+
+	c.Advance(30 * time.Second)
+	select {
+	case <-timer.C():
+		t.Errorf("timer fired before its deadline")
+	default:
+	}
+
+	c.Advance(30 * time.Second)
+	select {
+	case got := <-timer.C():
+		if !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("timer fired at %+v ,expected %+v", got, start.Add(time.Minute))
+		}
+	default:
+		t.Errorf("timer did not fire")
+	}
+
+	if timer.Stop() {
+		t.Errorf("Stop = true ,expected false for a fired timer")
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	expected := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	SetClock(NewFakeClock(expected))
+	defer SetClock(nil)
+
+	if !Now().Equal(expected) {
+		t.Errorf("Now = %+v ,expected %+v", Now(), expected)
+	}
+}
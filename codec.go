@@ -0,0 +1,515 @@
+package timkit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTagName is the struct tag read by a Codec without a tag name
+const DefaultTagName = "timkit"
+
+var ErrCodecTarget = errors.New("timkit: codec needs a non-nil pointer to a struct")
+
+var (
+	timeKitType     = reflect.TypeOf((*TimeKit)(nil)).Elem()
+	nullTimeKitType = reflect.TypeOf(NullTimeKit{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// tagLayouts are the layout names accepted by the tag
+var tagLayouts = map[string]string{
+	"date":     DateFormat,
+	"datetime": DefaultFormat,
+	"time":     TimeFormat,
+	"rfc3339":  time.RFC3339,
+}
+
+// Codec encode and decode structs as JSON , applying the struct tag of their TimeKit fields .
+// The tag holds a layout , a location and a wire format , e.g.
+//
+//	Birthday *timkit.TimeKit `json:"birthday" timkit:"layout=2006-01-02,loc=Asia/Shanghai"`
+//	Created  timkit.TimeKit  `json:"created" timkit:"unixmilli"`
+//
+// The tag applies to TimeKit , *TimeKit , NullTimeKit and *NullTimeKit fields ,
+// in the struct and in its nested structs and pointers to structs
+type Codec struct {
+	// TagName is the struct tag read by the codec , empty for DefaultTagName
+	TagName string
+
+	cache sync.Map
+}
+
+// NewCodec return a new Codec reading the given struct tag
+func NewCodec(tagName string) *Codec {
+	return &Codec{TagName: tagName}
+}
+
+// tagOptions are the settings read from the tag of a field
+type tagOptions struct {
+	layout   string
+	location *time.Location
+	wire     WireFormat
+}
+
+// parseTag parse `layout=...,loc=...,unix` , the layout may contain commas
+func parseTag(tag string) (tagOptions, error) {
+	var opts tagOptions
+	var parts []string
+	for _, p := range strings.Split(tag, ",") {
+		key := strings.TrimSpace(p)
+		if i := strings.Index(key, "="); i >= 0 {
+			key = key[:i]
+		}
+		switch key {
+		case "layout", "loc", "unix", "unixmilli", "rfc3339", "":
+			parts = append(parts, p)
+		default:
+			if len(parts) == 0 {
+				return opts, fmt.Errorf("timkit: invalid tag option %q", p)
+			}
+			parts[len(parts)-1] += "," + p
+		}
+	}
+
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		switch {
+		case p == "":
+		case p == "unix":
+			opts.wire = WireUnix
+		case p == "unixmilli":
+			opts.wire = WireUnixMilli
+		case p == "rfc3339":
+			opts.wire = WireRFC3339
+		case strings.HasPrefix(p, "layout="):
+			opts.layout = strings.TrimPrefix(p, "layout=")
+			if named, ok := tagLayouts[opts.layout]; ok {
+				opts.layout = named
+			}
+			if opts.wire == WireDefault {
+				opts.wire = WireLayout
+			}
+		case strings.HasPrefix(p, "loc="):
+			l, err := loadLocation(strings.TrimPrefix(p, "loc="))
+			if err != nil {
+				return opts, err
+			}
+			opts.location = l
+		}
+	}
+	return opts, nil
+}
+
+// apply return a copy of tk with the settings of the tag
+func (opts tagOptions) apply(tk *TimeKit) *TimeKit {
+	c := tk.Copy()
+	if opts.location != nil {
+		c.Time = c.In(opts.location)
+	}
+	if opts.layout != "" {
+		c.format = opts.layout
+	}
+	if opts.wire != WireDefault {
+		c.wireFormat = opts.wire
+	}
+	return c
+}
+
+// target return an empty TimeKit configured by the tag to decode into
+func (opts tagOptions) target() *TimeKit {
+	l := opts.location
+	if l == nil {
+		l = time.Local
+	}
+	return opts.apply(NewTimeKit(time.Time{}.In(l)))
+}
+
+type fieldKind int
+
+const (
+	fieldPlain fieldKind = iota
+	fieldTime
+	fieldStruct
+)
+
+// mirrorField is a field of the mirror of a struct
+type mirrorField struct {
+	name    string
+	tag     reflect.StructTag
+	index   []int
+	kind    fieldKind
+	typ     reflect.Type
+	opts    tagOptions
+	nested  *mirrorType
+	pointer bool
+}
+
+// mirrorType is a struct type in which the tagged time fields are json.RawMessage ,
+// the embedded structs are flattened as encoding/json does
+type mirrorType struct {
+	typ    reflect.Type
+	fields []mirrorField
+}
+
+func (c *Codec) tagName() string {
+	if c.TagName == "" {
+		return DefaultTagName
+	}
+	return c.TagName
+}
+
+func isTimeType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == timeKitType || t == nullTimeKitType
+}
+
+// structType return the struct type of t or *t , nil for the other types
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isTimeType(t) || t == reflect.TypeOf(time.Time{}) {
+		return nil
+	}
+	return t
+}
+
+// mirror return the mirror of the struct type t , nil when it has no tagged field
+func (c *Codec) mirror(t reflect.Type) (*mirrorType, error) {
+	if m, ok := c.cache.Load(t); ok {
+		return m.(*mirrorType), nil
+	}
+	m, err := c.buildMirror(t, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Store(t, m)
+	return m, nil
+}
+
+func (c *Codec) buildMirror(t reflect.Type, visiting map[reflect.Type]bool) (*mirrorType, error) {
+	if visiting[t] {
+		return nil, nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	fields, tagged, err := c.mirrorFields(t, visiting)
+	if err != nil || !tagged {
+		return nil, err
+	}
+	// a field hides the fields of the same name deeper in the embedded structs
+	depth := make(map[string]int)
+	for _, f := range fields {
+		if d, ok := depth[f.name]; !ok || len(f.index) < d {
+			depth[f.name] = len(f.index)
+		}
+	}
+	m := &mirrorType{}
+	sf := make([]reflect.StructField, 0, len(fields))
+	for _, f := range fields {
+		if depth[f.name] != len(f.index) {
+			continue
+		}
+		depth[f.name] = -1
+		m.fields = append(m.fields, f)
+		sf = append(sf, reflect.StructField{Name: f.name, Type: f.typ, Tag: f.tag})
+	}
+	m.typ = reflect.StructOf(sf)
+	return m, nil
+}
+
+// mirrorFields return the fields of the mirror of t and whether one of them is tagged
+func (c *Codec) mirrorFields(t reflect.Type, visiting map[reflect.Type]bool) ([]mirrorField, bool, error) {
+	var fields []mirrorField
+	tagged := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		if f.Anonymous && name == "" {
+			if st := structType(f.Type); st != nil {
+				embedded, ok, err := c.mirrorFields(st, visiting)
+				if err != nil {
+					return nil, false, err
+				}
+				for _, e := range embedded {
+					e.index = append([]int{i}, e.index...)
+					fields = append(fields, e)
+				}
+				tagged = tagged || ok
+				continue
+			}
+		}
+		if f.PkgPath != "" || name == "-" {
+			continue
+		}
+
+		mf := mirrorField{name: f.Name, tag: f.Tag, index: []int{i}, typ: f.Type}
+		if tag, ok := f.Tag.Lookup(c.tagName()); ok && isTimeType(f.Type) {
+			opts, err := parseTag(tag)
+			if err != nil {
+				return nil, false, err
+			}
+			mf.kind, mf.typ, mf.opts = fieldTime, rawMessageType, opts
+			tagged = true
+		} else if st := structType(f.Type); st != nil {
+			nested, err := c.buildMirror(st, visiting)
+			if err != nil {
+				return nil, false, err
+			}
+			if nested != nil {
+				mf.kind, mf.nested, mf.pointer, mf.typ = fieldStruct, nested, f.Type.Kind() == reflect.Ptr, nested.typ
+				if mf.pointer {
+					mf.typ = reflect.PtrTo(nested.typ)
+				}
+				tagged = true
+			}
+		}
+		fields = append(fields, mf)
+	}
+	return fields, tagged, nil
+}
+
+// field return the field at index , nil when a nil embedded pointer is on the way .
+// With alloc the nil pointers are allocated
+func field(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// Marshal return the JSON encoding of v , applying the struct tags of its time fields
+func (c *Codec) Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return json.Marshal(v)
+	}
+	m, err := c.mirror(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return json.Marshal(v)
+	}
+	if !rv.CanAddr() {
+		p := reflect.New(rv.Type()).Elem()
+		p.Set(rv)
+		rv = p
+	}
+	out, err := m.encode(rv)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(out.Interface())
+}
+
+func (m *mirrorType) encode(v reflect.Value) (reflect.Value, error) {
+	out := reflect.New(m.typ).Elem()
+	for i, f := range m.fields {
+		src, ok := field(v, f.index, false)
+		if !ok {
+			continue
+		}
+		dst := out.Field(i)
+		switch f.kind {
+		case fieldTime:
+			tk := timeKitOf(src)
+			if tk == nil {
+				continue
+			}
+			data, err := f.opts.apply(tk).MarshalJSON()
+			if err != nil {
+				return out, err
+			}
+			dst.SetBytes(data)
+		case fieldStruct:
+			if f.pointer {
+				if src.IsNil() {
+					continue
+				}
+				src = src.Elem()
+			}
+			nested, err := f.nested.encode(src)
+			if err != nil {
+				return out, err
+			}
+			if f.pointer {
+				nested = nested.Addr()
+			}
+			dst.Set(nested)
+		default:
+			dst.Set(src)
+		}
+	}
+	return out, nil
+}
+
+// timeKitOf return the TimeKit held by a time field , nil when it is null
+func timeKitOf(v reflect.Value) *TimeKit {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Type() == nullTimeKitType {
+		return v.Interface().(NullTimeKit).Ptr()
+	}
+	return v.Addr().Interface().(*TimeKit)
+}
+
+// Unmarshal decode the JSON data into v , applying the struct tags of its time fields .
+// v must be a pointer to a struct
+func (c *Codec) Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrCodecTarget
+	}
+	m, err := c.mirror(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return json.Unmarshal(data, v)
+	}
+	out := reflect.New(m.typ)
+	if err := m.preset(rv.Elem(), out.Elem()); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out.Interface()); err != nil {
+		return err
+	}
+	return m.decode(out.Elem(), rv.Elem())
+}
+
+// preset copy the current plain values into the mirror , as json.Unmarshal keeps the absent fields
+func (m *mirrorType) preset(v, out reflect.Value) error {
+	for i, f := range m.fields {
+		src, ok := field(v, f.index, false)
+		if !ok {
+			continue
+		}
+		switch f.kind {
+		case fieldPlain:
+			out.Field(i).Set(src)
+		case fieldStruct:
+			if f.pointer {
+				if src.IsNil() {
+					continue
+				}
+				src = src.Elem()
+				out.Field(i).Set(reflect.New(f.nested.typ))
+			}
+			if err := f.nested.preset(src, reflect.Indirect(out.Field(i))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mirrorType) decode(out, v reflect.Value) error {
+	for i, f := range m.fields {
+		src := out.Field(i)
+		if f.kind == fieldTime && len(src.Bytes()) == 0 {
+			continue
+		}
+		dst, ok := field(v, f.index, f.kind != fieldPlain || !src.IsZero())
+		if !ok {
+			continue
+		}
+		switch f.kind {
+		case fieldTime:
+			if err := decodeTimeField(src.Bytes(), f.opts, dst); err != nil {
+				return err
+			}
+		case fieldStruct:
+			if f.pointer {
+				if src.IsNil() {
+					dst.Set(reflect.Zero(dst.Type()))
+					continue
+				}
+				if dst.IsNil() {
+					dst.Set(reflect.New(dst.Type().Elem()))
+				}
+				src, dst = src.Elem(), dst.Elem()
+			}
+			if err := f.nested.decode(src, dst); err != nil {
+				return err
+			}
+		default:
+			dst.Set(src)
+		}
+	}
+	return nil
+}
+
+// decodeTimeField decode a JSON value into a TimeKit , *TimeKit , NullTimeKit or *NullTimeKit
+func decodeTimeField(data []byte, opts tagOptions, dst reflect.Value) error {
+	null := strings.TrimSpace(string(data)) == "null"
+	if dst.Kind() == reflect.Ptr {
+		if null {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	if dst.Type() == nullTimeKitType {
+		n := dst.Addr().Interface().(*NullTimeKit)
+		if null {
+			n.Valid = false
+			return nil
+		}
+		n.TimeKit = opts.target()
+		n.Valid = true
+		return n.TimeKit.UnmarshalJSON(data)
+	}
+	if null {
+		return nil
+	}
+	tk := opts.target()
+	if err := tk.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	dst.Addr().Interface().(*TimeKit).set(tk)
+	return nil
+}
+
+// set copy the time and the settings of src
+func (tk *TimeKit) set(src *TimeKit) {
+	c := src.Copy()
+	tk.lock.Lock()
+	defer tk.lock.Unlock()
+	tk.Time = c.Time
+	tk.format = c.format
+	tk.weekendDays = c.weekendDays
+	tk.weekStartAt = c.weekStartAt
+	tk.weekEndAt = c.weekEndAt
+	tk.wireFormat = c.wireFormat
+	tk.storageMode = c.storageMode
+	tk.wallTimePolicy = c.wallTimePolicy
+}
@@ -0,0 +1,75 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+type codecBase struct {
+	Created *TimeKit `json:"created" timkit:"unixmilli"`
+}
+
+type codecProfile struct {
+	codecBase
+	Name     string      `json:"name"`
+	Birthday TimeKit     `json:"birthday" timkit:"layout=date,loc=Asia/Shanghai"`
+	Seen     NullTimeKit `json:"seen" timkit:"layout=Mon, 02 Jan 2006 15:04 MST,loc=UTC"`
+	Plain    *TimeKit    `json:"plain,omitempty"`
+	Next     *codecNext  `json:"next,omitempty"`
+}
+
+type codecNext struct {
+	At *TimeKit `json:"at" timkit:"unix"`
+}
+
+func TestCodec_Marshal(t *testing.T) {
+	tm := time.Date(2021, 1, 2, 20, 4, 5, 6000000, time.UTC)
+	p := codecProfile{
+		codecBase: codecBase{Created: NewTimeKit(tm)},
+		Name:      "gopher",
+		Seen:      NewNullTimeKit(NewTimeKit(tm)),
+		Next:      &codecNext{At: NewTimeKit(tm)},
+	}
+	p.Birthday.set(NewTimeKit(tm))
+
+	data, err := NewCodec("").Marshal(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"created":1609617845006,"name":"gopher","birthday":"2021-01-03","seen":"Sat, 02 Jan 2021 20:04 UTC","next":{"at":1609617845}}`
+	if string(data) != expected {
+		t.Errorf("Marshal = %s ,expected %s", data, expected)
+	}
+}
+
+func TestCodec_Unmarshal(t *testing.T) {
+	var p codecProfile
+	data := `{"created":1609617845006,"name":"gopher","birthday":"2021-01-03","seen":null,"next":{"at":1609617845}}`
+	if err := NewCodec("").Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	created := time.Date(2021, 1, 2, 20, 4, 5, 6000000, time.UTC)
+	if p.Created == nil || !p.Created.Equal(created) {
+		t.Errorf("Created = %+v ,expected %+v", p.Created, created)
+	}
+	if p.Birthday.Location().String() != "Asia/Shanghai" || p.Birthday.String() != "2021-01-03" {
+		t.Errorf("Birthday = %+v in %+v ,expected 2021-01-03 in Asia/Shanghai", p.Birthday.String(), p.Birthday.Location())
+	}
+	if p.Name != "gopher" || p.Seen.Valid || p.Plain != nil {
+		t.Errorf("Unmarshal = %+v %+v %+v ,expected the plain fields", p.Name, p.Seen, p.Plain)
+	}
+	if p.Next == nil || p.Next.At.Unix() != 1609617845 {
+		t.Errorf("Next = %+v ,expected %+v", p.Next, 1609617845)
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	opts, err := parseTag("layout=Mon, 02 Jan 2006,loc=UTC,unixmilli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.layout != "Mon, 02 Jan 2006" || opts.location != time.UTC || opts.wire != WireUnixMilli {
+		t.Errorf("parseTag = %+v ,expected the layout , UTC and unixmilli", opts)
+	}
+}
@@ -0,0 +1,138 @@
+package timkit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// configTime is the mapping form of a time in a config , e.g. in YAML
+//
+//	start:
+//	  time: 2021-01-02 09:00
+//	  location: Asia/Shanghai
+type configTime struct {
+	Time     string
+	Location string
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface of gopkg.in/yaml.v2 , also supported by yaml.v3 .
+// A scalar is parsed like UnmarshalConfig , a mapping has the keys `time` and `location`
+func (tk *TimeKit) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		return tk.UnmarshalConfig(s, "")
+	}
+	var c configTime
+	if err := unmarshal(&c); err != nil {
+		return err
+	}
+	return tk.UnmarshalConfig(c.Time, c.Location)
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface of github.com/BurntSushi/toml .
+// It accepts strings , integers , TOML datetimes and tables with the keys `time` and `location`
+func (tk *TimeKit) UnmarshalTOML(v interface{}) error {
+	switch value := v.(type) {
+	case string:
+		return tk.UnmarshalConfig(value, "")
+	case int64:
+		return tk.UnmarshalConfig(fmt.Sprint(value), "")
+	case time.Time:
+		return tk.setConfigTime(value, "")
+	case map[string]interface{}:
+		location, _ := value["location"].(string)
+		switch t := value["time"].(type) {
+		case string:
+			return tk.UnmarshalConfig(t, location)
+		case int64:
+			return tk.UnmarshalConfig(fmt.Sprint(t), location)
+		case time.Time:
+			return tk.setConfigTime(t, location)
+		}
+	}
+	return fmt.Errorf("timkit: cannot decode %T as a time", v)
+}
+
+// UnmarshalConfig parse a time of a config file in the given location , which may be empty .
+// The value is a relative expression of ParseRelative , a time in the format of the instance
+// or in ParseLayouts , or a unix timestamp . It may end with an inline zone , which wins over
+// the location , such as "2021-01-02 09:00 Asia/Shanghai" or "2021-01-02 09:00[Asia/Shanghai]"
+func (tk *TimeKit) UnmarshalConfig(value, location string) error {
+	l, err := tk.configLocation(location)
+	if err != nil {
+		return err
+	}
+	t, err := parseInput(value, l, append([]string{tk.format}, ParseLayouts...))
+	if err != nil {
+		return err
+	}
+	tk.SetTime(t)
+	return nil
+}
+
+// configLocation return the location of a config value , the location of the instance by default
+func (tk *TimeKit) configLocation(location string) (*time.Location, error) {
+	l := tk.initSettings()
+	if location == "" {
+		return l, nil
+	}
+	return loadLocation(location)
+}
+
+// setConfigTime set a time decoded by a parser . The local date-times of TOML have no offset ,
+// so their wall clock is kept in the location , other times are converted to it
+func (tk *TimeKit) setConfigTime(t time.Time, location string) error {
+	l, err := tk.configLocation(location)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(t.Location().String(), "-local") {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), l)
+	} else if location != "" {
+		t = t.In(l)
+	}
+	tk.SetTime(t)
+	return nil
+}
+
+// parseInput parse a time typed by a person : an optional inline zone , then a relative expression
+// resolved against the package clock , a time in one of the layouts or a unix timestamp .
+// A value whose last word only looks like a zone , e.g. in time.RFC1123 , is parsed as a whole
+func parseInput(value string, l *time.Location, layouts []string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if rest, zone := splitZone(value); zone != nil {
+		if t, err := parseInput(rest, zone, layouts); err == nil {
+			return t, nil
+		}
+	}
+	if tk, err := ParseRelative(value, NewTimeKit(now().In(l))); err == nil {
+		return tk.Time, nil
+	}
+	return parseTime(value, l, false, layouts...)
+}
+
+// splitZone split an inline zone from the end of a value , either in brackets
+// or after a space when it looks like a zone name , e.g. "UTC" or "Europe/Paris"
+func splitZone(value string) (string, *time.Location) {
+	if strings.HasSuffix(value, "]") {
+		if i := strings.LastIndex(value, "["); i >= 0 {
+			if l, err := loadLocation(value[i+1 : len(value)-1]); err == nil {
+				return strings.TrimSpace(value[:i]), l
+			}
+		}
+		return value, nil
+	}
+	i := strings.LastIndex(value, " ")
+	if i < 0 {
+		return value, nil
+	}
+	name := value[i+1:]
+	if name != "UTC" && !strings.Contains(name, "/") {
+		return value, nil
+	}
+	if l, err := loadLocation(name); err == nil {
+		return strings.TrimSpace(value[:i]), l
+	}
+	return value, nil
+}
@@ -0,0 +1,93 @@
+package timkit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// yamlFunc adapt a JSON document to the unmarshal function given to UnmarshalYAML
+func yamlFunc(doc string) func(interface{}) error {
+	return func(v interface{}) error {
+		return json.Unmarshal([]byte(doc), v)
+	}
+}
+
+func TestTimeKit_UnmarshalYAML(t *testing.T) {
+	SetClock(NewFakeClock(time.Date(2021, 1, 9, 15, 4, 5, 0, time.UTC)))
+	defer SetClock(nil)
+
+	tests := []struct {
+		doc      string
+		expected time.Time
+		location string
+	}{
+		{`"2021-01-02 09:00 Asia/Shanghai"`, time.Date(2021, 1, 2, 1, 0, 0, 0, time.UTC), "Asia/Shanghai"},
+		{`"2021-01-02 09:00[UTC]"`, time.Date(2021, 1, 2, 9, 0, 0, 0, time.UTC), "UTC"},
+		{`"Sat, 02 Jan 2021 09:00:00 UTC"`, time.Date(2021, 1, 2, 9, 0, 0, 0, time.UTC), "UTC"},
+		{`"1609599845"`, time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC), "Local"},
+		{`"-7d"`, time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC), "Local"},
+		{`{"time": "startOfDay", "location": "America/New_York"}`, time.Date(2021, 1, 9, 5, 0, 0, 0, time.UTC), "America/New_York"},
+		{`{"time": "2021-01-02 09:00", "location": "America/New_York"}`, time.Date(2021, 1, 2, 14, 0, 0, 0, time.UTC), "America/New_York"},
+	}
+	for _, test := range tests {
+		var tk TimeKit
+		if err := tk.UnmarshalYAML(yamlFunc(test.doc)); err != nil {
+			t.Errorf("UnmarshalYAML(%s) error %v", test.doc, err)
+			continue
+		}
+		if !tk.Equal(test.expected) || !inLocation(&tk, test.location) {
+			t.Errorf("UnmarshalYAML(%s) = %+v ,expected %+v in %s", test.doc, tk.Time, test.expected, test.location)
+		}
+	}
+
+	var tk TimeKit
+	if err := tk.UnmarshalYAML(yamlFunc(`"sometime"`)); err == nil {
+		t.Errorf("UnmarshalYAML ,expected an error")
+	}
+	if err := tk.UnmarshalYAML(yamlFunc(`{"time": "today", "location": "Nowhere/City"}`)); err == nil {
+		t.Errorf("UnmarshalYAML ,expected an error")
+	}
+}
+
+func TestTimeKit_UnmarshalTOML(t *testing.T) {
+	local := time.FixedZone("datetime-local", 0)
+	tests := []struct {
+		value    interface{}
+		expected time.Time
+		location string
+	}{
+		{"2021-01-02 09:00 Asia/Shanghai", time.Date(2021, 1, 2, 1, 0, 0, 0, time.UTC), "Asia/Shanghai"},
+		{int64(1609599845), time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC), "Local"},
+		{time.Date(2021, 1, 2, 9, 0, 0, 0, time.UTC), time.Date(2021, 1, 2, 9, 0, 0, 0, time.UTC), "UTC"},
+		{map[string]interface{}{"time": time.Date(2021, 1, 2, 9, 0, 0, 0, local), "location": "Asia/Shanghai"},
+			time.Date(2021, 1, 2, 1, 0, 0, 0, time.UTC), "Asia/Shanghai"},
+		{map[string]interface{}{"time": time.Date(2021, 1, 2, 9, 0, 0, 0, time.UTC), "location": "Asia/Shanghai"},
+			time.Date(2021, 1, 2, 9, 0, 0, 0, time.UTC), "Asia/Shanghai"},
+		{map[string]interface{}{"time": "2021-01-02 09:00", "location": "UTC"}, time.Date(2021, 1, 2, 9, 0, 0, 0, time.UTC), "UTC"},
+	}
+	for _, test := range tests {
+		var tk TimeKit
+		if err := tk.UnmarshalTOML(test.value); err != nil {
+			t.Errorf("UnmarshalTOML(%v) error %v", test.value, err)
+			continue
+		}
+		if !tk.Equal(test.expected) || !inLocation(&tk, test.location) {
+			t.Errorf("UnmarshalTOML(%v) = %+v ,expected %+v in %s", test.value, tk.Time, test.expected, test.location)
+		}
+	}
+
+	var tk TimeKit
+	if err := tk.UnmarshalTOML(true); err == nil {
+		t.Errorf("UnmarshalTOML ,expected an error")
+	}
+}
+
+// inLocation whether tk is in the location named name , Local is time.Local whose name
+// follows $TZ when it is set
+func inLocation(tk *TimeKit, name string) bool {
+	if name == "Local" {
+		return tk.Location() == time.Local
+	}
+	return tk.Location().String() == name
+}
@@ -0,0 +1,156 @@
+package timkit
+
+import (
+	"time"
+)
+
+// TransitionHorizon is how far NextTransition and PreviousTransition search a transition
+const TransitionHorizon = 5 * daysInNormalYear * hoursPerDay * time.Hour
+
+// transitionStep is the step of the search , two transitions closer than a step which
+// come back to the same zone are missed
+const transitionStep = hoursPerDay * minutesPerHour * secondsPerMinute
+
+// Transition is a change of the offset or the name of the zone of a location ,
+// the offsets are in seconds east of UTC
+type Transition struct {
+	At         *TimeKit
+	FromName   string
+	FromOffset int
+	ToName     string
+	ToOffset   int
+	// IsDST whether the zone after the transition is the daylight saving time , see IsDST
+	IsDST bool
+}
+
+// Shift return how the wall clock moves at the transition , e.g. 1h when the daylight
+// saving time begins and -1h when it ends
+func (t Transition) Shift() time.Duration {
+	return time.Duration(t.ToOffset-t.FromOffset) * time.Second
+}
+
+// StandardOffset return the offset of the standard time in the year of tk in seconds east of UTC ,
+// the smaller offset of January and July
+func (tk *TimeKit) StandardOffset() int {
+	std, _ := yearOffsets(tk.Year(), tk.Location())
+	return std
+}
+
+// DaylightOffset return the offset of the daylight saving time in the year of tk in seconds
+// east of UTC , the larger offset of January and July . It is StandardOffset without daylight saving time
+func (tk *TimeKit) DaylightOffset() int {
+	_, dst := yearOffsets(tk.Year(), tk.Location())
+	return dst
+}
+
+// IsDST whether tk is in the daylight saving time , as flagged by the time zone data of its location .
+// A permanent change of the offset is not a daylight saving time , even when it moves the clock forward
+func (tk *TimeKit) IsDST() bool {
+	return tk.Time.IsDST()
+}
+
+func yearOffsets(year int, l *time.Location) (int, int) {
+	_, jan := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).In(l).Zone()
+	_, jul := time.Date(year, time.July, 1, 0, 0, 0, 0, time.UTC).In(l).Zone()
+	if jan > jul {
+		return jul, jan
+	}
+	return jan, jul
+}
+
+// HoursInDay return the number of hours of the local day of tk , 23 or 25 when the daylight
+// saving time begins or ends , 23.5 or 24.5 for the zones shifting 30 minutes . The day lasts
+// from its first instant , after the gap when its midnight is skipped , to the first instant of the next day
+func (tk *TimeKit) HoursInDay() float64 {
+	start := startOfDate(tk.Year(), tk.Month(), tk.Day(), tk.Location())
+	end := startOfDate(tk.Year(), tk.Month(), tk.Day()+1, tk.Location())
+	return end.Sub(start).Hours()
+}
+
+// NextTransition return the first transition of the location of tk after tk ,
+// false if there is none within TransitionHorizon
+func (tk *TimeKit) NextTransition() (Transition, bool) {
+	sec := tk.Unix()
+	transitions := tk.transitions(sec, sec+int64(TransitionHorizon/time.Second), 1)
+	if len(transitions) == 0 {
+		return Transition{}, false
+	}
+	return transitions[0], true
+}
+
+// PreviousTransition return the last transition of the location of tk at or before tk ,
+// false if there is none within TransitionHorizon
+func (tk *TimeKit) PreviousTransition() (Transition, bool) {
+	l := tk.Location()
+	hi := tk.Unix()
+	name, offset := zoneAt(l, hi)
+	for limit := hi - int64(TransitionHorizon/time.Second); hi > limit; hi -= transitionStep {
+		lo := hi - transitionStep
+		if n, o := zoneAt(l, lo); n != name || o != offset {
+			return tk.transition(searchTransition(l, lo, hi)), true
+		}
+	}
+	return Transition{}, false
+}
+
+// Transitions return the transitions of the location of a in [a, b)
+func Transitions(a, b *TimeKit) []Transition {
+	lo, hi := a.Unix()-1, b.Unix()
+	if a.Nanosecond() > 0 {
+		lo++
+	}
+	if b.Nanosecond() == 0 {
+		hi--
+	}
+	return a.transitions(lo, hi, 0)
+}
+
+// transitions return at most n transitions in (lo, hi] , all of them if n is 0
+func (tk *TimeKit) transitions(lo, hi int64, n int) []Transition {
+	l := tk.Location()
+	var transitions []Transition
+	for lo < hi && (n == 0 || len(transitions) < n) {
+		next := lo + transitionStep
+		if next > hi {
+			next = hi
+		}
+		name, offset := zoneAt(l, lo)
+		if nextName, nextOffset := zoneAt(l, next); nextName == name && nextOffset == offset {
+			lo = next
+			continue
+		}
+		lo = searchTransition(l, lo, next)
+		transitions = append(transitions, tk.transition(lo))
+	}
+	return transitions
+}
+
+// transition return the transition at sec in the location of tk
+func (tk *TimeKit) transition(sec int64) Transition {
+	l := tk.Location()
+	t := Transition{At: tk.Copy()}
+	t.At.SetTime(time.Unix(sec, 0).In(l))
+	t.FromName, t.FromOffset = zoneAt(l, sec-1)
+	t.ToName, t.ToOffset = zoneAt(l, sec)
+	t.IsDST = t.At.IsDST()
+	return t
+}
+
+// searchTransition return the first second in (lo, hi] whose zone is not the zone of lo ,
+// the zone of hi must differ from the zone of lo
+func searchTransition(l *time.Location, lo, hi int64) int64 {
+	name, offset := zoneAt(l, lo)
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if n, o := zoneAt(l, mid); n == name && o == offset {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+func zoneAt(l *time.Location, sec int64) (string, int) {
+	return time.Unix(sec, 0).In(l).Zone()
+}
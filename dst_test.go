@@ -0,0 +1,121 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeKit_IsDST(t *testing.T) {
+	for _, c := range []struct {
+		location string
+		date     time.Time
+		dst      bool
+		std      int
+		daylight int
+	}{
+		{"America/New_York", time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC), true, -5 * 3600, -4 * 3600},
+		{"America/New_York", time.Date(2021, 12, 1, 12, 0, 0, 0, time.UTC), false, -5 * 3600, -4 * 3600},
+		{"Australia/Sydney", time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC), true, 10 * 3600, 11 * 3600},
+		{"Australia/Sydney", time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC), false, 10 * 3600, 11 * 3600},
+		{"Asia/Shanghai", time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC), false, 8 * 3600, 8 * 3600},
+		// the offset moved permanently from +08:30 to +09:00 on 2018-05-04
+		{"Asia/Pyongyang", time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC), false, 8*3600 + 1800, 9 * 3600},
+	} {
+		l, _ := LoadLocation(c.location)
+		tk := NewTimeKit(c.date.In(l))
+		if got := tk.IsDST(); got != c.dst {
+			t.Errorf("IsDST(%s %s) = %+v ,expected %+v", c.location, tk, got, c.dst)
+		}
+		if got := tk.StandardOffset(); got != c.std {
+			t.Errorf("StandardOffset(%s) = %+v ,expected %+v", c.location, got, c.std)
+		}
+		if got := tk.DaylightOffset(); got != c.daylight {
+			t.Errorf("DaylightOffset(%s) = %+v ,expected %+v", c.location, got, c.daylight)
+		}
+	}
+}
+
+func TestTimeKit_HoursInDay(t *testing.T) {
+	for _, c := range []struct {
+		location string
+		y, m, d  int
+		expected float64
+	}{
+		{"America/New_York", 2021, 3, 14, 23},
+		{"America/New_York", 2021, 11, 7, 25},
+		{"America/New_York", 2021, 11, 8, 24},
+		{"Australia/Lord_Howe", 2021, 10, 3, 23.5},
+		{"Asia/Shanghai", 2021, 3, 14, 24},
+		// the midnight is skipped
+		{"America/Havana", 2021, 3, 14, 23},
+		{"America/Havana", 2021, 3, 13, 24},
+		{"America/Santiago", 2021, 9, 5, 23},
+		{"America/Sao_Paulo", 2018, 11, 4, 23},
+		{"America/Sao_Paulo", 2018, 11, 3, 24},
+	} {
+		l, _ := LoadLocation(c.location)
+		tk := NewTimeKit(time.Date(c.y, time.Month(c.m), c.d, 15, 0, 0, 0, l))
+		if got := tk.HoursInDay(); got != c.expected {
+			t.Errorf("HoursInDay(%s %s) = %+v ,expected %+v", c.location, tk, got, c.expected)
+		}
+	}
+}
+
+func TestTimeKit_NextTransition(t *testing.T) {
+	l, _ := LoadLocation("America/New_York")
+	tk := NewTimeKit(time.Date(2021, 6, 1, 0, 0, 0, 0, l))
+
+	next, ok := tk.NextTransition()
+	expected := time.Date(2021, 11, 7, 6, 0, 0, 0, time.UTC)
+	if !ok || !next.At.Equal(expected) || next.At.Location() != l {
+		t.Fatalf("NextTransition = %s %v ,expected %s", next.At, ok, expected)
+	}
+	if next.FromName != "EDT" || next.ToName != "EST" || next.Shift() != -time.Hour {
+		t.Errorf("NextTransition = %s %s %s ,expected EDT EST -1h", next.FromName, next.ToName, next.Shift())
+	}
+
+	prev, ok := tk.PreviousTransition()
+	expected = time.Date(2021, 3, 14, 7, 0, 0, 0, time.UTC)
+	if !ok || !prev.At.Equal(expected) || prev.Shift() != time.Hour {
+		t.Errorf("PreviousTransition = %s %v ,expected %s", prev.At, ok, expected)
+	}
+	if again, _ := prev.At.PreviousTransition(); !again.At.Equal(expected) {
+		t.Errorf("PreviousTransition at a transition = %s ,expected %s", again.At, expected)
+	}
+	if again, _ := prev.At.NextTransition(); !again.At.Equal(next.At.Time) {
+		t.Errorf("NextTransition at a transition = %s ,expected %s", again.At, next.At)
+	}
+
+	l, _ = LoadLocation("Asia/Shanghai")
+	if _, ok := NewTimeKit(time.Date(2021, 6, 1, 0, 0, 0, 0, l)).NextTransition(); ok {
+		t.Errorf("NextTransition(Asia/Shanghai) ,expected none")
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	l, _ := LoadLocation("Europe/Berlin")
+	a := NewTimeKit(time.Date(2020, 1, 1, 0, 0, 0, 0, l))
+	b := NewTimeKit(time.Date(2022, 1, 1, 0, 0, 0, 0, l))
+	transitions := Transitions(a, b)
+	expected := []time.Time{
+		time.Date(2020, 3, 29, 1, 0, 0, 0, time.UTC),
+		time.Date(2020, 10, 25, 1, 0, 0, 0, time.UTC),
+		time.Date(2021, 3, 28, 1, 0, 0, 0, time.UTC),
+		time.Date(2021, 10, 31, 1, 0, 0, 0, time.UTC),
+	}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Transitions = %+v ,expected %+v", len(transitions), len(expected))
+	}
+	for i, tr := range transitions {
+		if !tr.At.Equal(expected[i]) {
+			t.Errorf("Transitions[%d] = %s ,expected %s", i, tr.At, expected[i])
+		}
+	}
+
+	// the range is half-open
+	a.SetTime(expected[0].In(l))
+	b.SetTime(expected[3].In(l))
+	if transitions := Transitions(a, b); len(transitions) != 3 || !transitions[0].At.Equal(expected[0]) {
+		t.Errorf("Transitions = %+v ,expected 3 from %s", len(transitions), expected[0])
+	}
+}
@@ -0,0 +1,198 @@
+package timkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WireFormat is how the marshalers write a TimeKit
+type WireFormat int
+
+const (
+	// WireDefault use DefaultWireFormat
+	WireDefault WireFormat = iota
+	// WireLayout write the time with the format of the instance
+	WireLayout
+	// WireRFC3339 write the time as RFC 3339 with nanoseconds
+	WireRFC3339
+	// WireUnix write the seconds since Jan 1 1970
+	WireUnix
+	// WireUnixMilli write the milliseconds since Jan 1 1970
+	WireUnixMilli
+)
+
+// DefaultWireFormat is the wire format of the instances without one , set it during initialization
+var DefaultWireFormat = WireLayout
+
+// ParseLayouts are the layouts tried in order by the unmarshalers , after the format of the instance
+var ParseLayouts = []string{
+	time.RFC3339Nano,
+	DefaultFormat,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	DateFormat,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+}
+
+// OptionSetWireFormat set how the marshalers write the time
+func OptionSetWireFormat(f WireFormat) Option {
+	return func(t *TimeKit) {
+		t.wireFormat = f
+	}
+}
+
+// SetWireFormat set how the marshalers write the time
+func (tk *TimeKit) SetWireFormat(f WireFormat) {
+	tk.lock.Lock()
+	defer tk.lock.Unlock()
+	tk.wireFormat = f
+}
+
+// WireFormat return how the marshalers write the time
+func (tk *TimeKit) WireFormat() WireFormat {
+	if tk.wireFormat == WireDefault {
+		return DefaultWireFormat
+	}
+	return tk.wireFormat
+}
+
+// initSettings set the default settings of a zero TimeKit , e.g. one allocated by a decoder .
+// It return the location used for the layouts without a zone
+func (tk *TimeKit) initSettings() *time.Location {
+	tk.lock.Lock()
+	defer tk.lock.Unlock()
+	if tk.weekendDays != nil {
+		return tk.Location()
+	}
+	if tk.format == "" {
+		tk.format = DefaultFormat
+	}
+	tk.weekendDays = []time.Weekday{time.Saturday, time.Sunday}
+	tk.weekStartAt = time.Monday
+	tk.weekEndAt = time.Sunday
+	return time.Local
+}
+
+// unixMilli return the milliseconds since Jan 1 1970
+func unixMilli(t time.Time) int64 {
+	return t.Unix()*1e3 + int64(t.Nanosecond())/1e6
+}
+
+// fromUnixMilli return the time of the given milliseconds since Jan 1 1970
+func fromUnixMilli(ms int64) time.Time {
+	return time.Unix(ms/1e3, (ms%1e3)*1e6)
+}
+
+// parseTime parse value with the given layouts in order , then as a unix timestamp
+func parseTime(value string, l *time.Location, milli bool, layouts ...string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		if layout == "" {
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, value, l); err == nil {
+			return t, nil
+		}
+	}
+	if t, ok := parseUnix(value, l, milli); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("timkit: cannot parse %q as a time", value)
+}
+
+// parseUnix parse a unix timestamp in seconds , or milliseconds if milli is true .
+// The values which are not finite or whose seconds overflow an int64 are rejected
+func parseUnix(value string, l *time.Location, milli bool) (time.Time, bool) {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if milli {
+			return fromUnixMilli(n).In(l), true
+		}
+		return time.Unix(n, 0).In(l), true
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if milli {
+		f /= 1e3
+	}
+	// NaN fails both comparisons , float64(math.MaxInt64) is 2^63 which overflows
+	if !(f >= math.MinInt64 && f < math.MaxInt64) {
+		return time.Time{}, false
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)).In(l), true
+}
+
+// MarshalText implements the encoding.TextMarshaler interface using the wire format ,
+// see MarshalJSON for the fields which are not pointers
+func (tk *TimeKit) MarshalText() ([]byte, error) {
+	switch tk.WireFormat() {
+	case WireRFC3339:
+		return []byte(tk.Format(time.RFC3339Nano)), nil
+	case WireUnix:
+		return []byte(strconv.FormatInt(tk.Unix(), 10)), nil
+	case WireUnixMilli:
+		return []byte(strconv.FormatInt(unixMilli(tk.Time), 10)), nil
+	}
+	return []byte(tk.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface .
+// It accepts the format of the instance , ParseLayouts and unix timestamps
+func (tk *TimeKit) UnmarshalText(data []byte) error {
+	l := tk.initSettings()
+	t, err := parseTime(string(data), l, tk.WireFormat() == WireUnixMilli, append([]string{tk.format}, ParseLayouts...)...)
+	if err != nil {
+		return err
+	}
+	tk.SetTime(t)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface using the wire format .
+// Unix timestamps are written as numbers , the other formats as strings .
+// The marshalers have pointer receivers : a TimeKit field which is not a pointer honours the
+// wire format only when it is addressable , e.g. json.Marshal(&v) , else json.Marshal(v) falls
+// back to the MarshalJSON of the embedded time.Time and writes RFC 3339 . Use *TimeKit fields
+func (tk *TimeKit) MarshalJSON() ([]byte, error) {
+	text, err := tk.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	switch tk.WireFormat() {
+	case WireUnix, WireUnixMilli:
+		return text, nil
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface , null is a no-op as for time.Time
+func (tk *TimeKit) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return tk.UnmarshalText([]byte(s))
+	}
+	l := tk.initSettings()
+	t, ok := parseUnix(string(data), l, tk.WireFormat() == WireUnixMilli)
+	if !ok {
+		return fmt.Errorf("timkit: cannot parse %s as a time", data)
+	}
+	tk.SetTime(t)
+	return nil
+}
@@ -0,0 +1,104 @@
+package timkit
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeKit_MarshalJSON(t *testing.T) {
+	tm := time.Date(2021, 1, 2, 15, 4, 5, 6000000, time.UTC)
+	cases := []struct {
+		tk       *TimeKit
+		expected string
+	}{
+		{NewTimeKit(tm), `"2021-01-02 15:04:05"`},
+		{NewOptions(OptionSetTime(tm), OptionSetFormat(DateFormat)), `"2021-01-02"`},
+		{NewOptions(OptionSetTime(tm), OptionSetWireFormat(WireRFC3339)), `"2021-01-02T15:04:05.006Z"`},
+		{NewOptions(OptionSetTime(tm), OptionSetWireFormat(WireUnix)), `1609599845`},
+		{NewOptions(OptionSetTime(tm), OptionSetWireFormat(WireUnixMilli)), `1609599845006`},
+	}
+	for _, c := range cases {
+		data, err := json.Marshal(c.tk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != c.expected {
+			t.Errorf("MarshalJSON = %s ,expected %s", data, c.expected)
+		}
+	}
+}
+
+func TestTimeKit_MarshalJSON_ValueField(t *testing.T) {
+	type event struct {
+		At TimeKit `json:"at"`
+	}
+	var e event
+	e.At.SetTime(time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC))
+	e.At.SetFormat(DateFormat)
+
+	// the field is addressable through a pointer
+	data, err := json.Marshal(&e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"at":"2021-01-02"}`; string(data) != expected {
+		t.Errorf("MarshalJSON(&v) = %s ,expected %s", data, expected)
+	}
+
+	// else the MarshalJSON of the embedded time.Time is used
+	data, err = json.Marshal(reflect.ValueOf(&e).Elem().Interface())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"at":"2021-01-02T15:04:05Z"}`; string(data) != expected {
+		t.Errorf("MarshalJSON(v) = %s ,expected %s", data, expected)
+	}
+}
+
+func TestTimeKit_UnmarshalJSON(t *testing.T) {
+	expected := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	inputs := []string{
+		`"2021-01-02T15:04:05Z"`,
+		`"Sat, 02 Jan 2021 15:04:05 +0000"`,
+		`1609599845`,
+		`"1609599845"`,
+	}
+	for _, in := range inputs {
+		var v struct {
+			At *TimeKit `json:"at"`
+		}
+		if err := json.Unmarshal([]byte(`{"at":`+in+`}`), &v); err != nil {
+			t.Fatal(err)
+		}
+		if !v.At.Equal(expected) {
+			t.Errorf("UnmarshalJSON(%s) = %+v ,expected %+v", in, v.At, expected)
+		}
+		if v.At.format != DefaultFormat || v.At.weekStartAt != time.Monday || v.At.weekEndAt != time.Sunday ||
+			!reflect.DeepEqual(v.At.WeekendDays(), []time.Weekday{time.Saturday, time.Sunday}) {
+			t.Errorf("UnmarshalJSON(%s) did not initialize the settings", in)
+		}
+	}
+}
+
+func TestTimeKit_UnmarshalText(t *testing.T) {
+	tk := NewOptions(OptionSetTime(time.Time{}.In(time.UTC)), OptionSetWireFormat(WireUnixMilli))
+	if err := tk.UnmarshalText([]byte("1609599845006")); err != nil {
+		t.Fatal(err)
+	}
+	expected := time.Date(2021, 1, 2, 15, 4, 5, 6000000, time.UTC)
+	if !tk.Equal(expected) {
+		t.Errorf("UnmarshalText = %+v ,expected %+v", tk.Time, expected)
+	}
+
+	// the values which are not finite or overflow the seconds
+	for _, in := range []string{"NaN", "Inf", "-Inf", "1e300", "1e25"} {
+		if err := tk.UnmarshalText([]byte(in)); err == nil {
+			t.Errorf("UnmarshalText(%s) = %+v ,expected an error", in, tk.Time)
+		}
+		if err := tk.UnmarshalJSON([]byte(in)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %+v ,expected an error", in, tk.Time)
+		}
+	}
+}
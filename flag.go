@@ -0,0 +1,89 @@
+package timkit
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+// TimeFlag is a command line flag holding a TimeKit , it implements flag.Value , flag.Getter
+// and the Type method of pflag.Value . It accepts
+//
+//	absolute times in Layouts , e.g. "2021-01-02" or "2021-01-02T15:04:05+08:00"
+//	unix timestamps in seconds , e.g. "1609599845"
+//	relative expressions of ParseRelative , e.g. "-7d" , "yesterday" , "now-1h" or "startOfWeek"
+//
+// followed by an optional inline zone , e.g. "2021-01-02 09:00 Asia/Shanghai" or "today[UTC]" .
+// Relative expressions are resolved against the package clock
+type TimeFlag struct {
+	// TimeKit is the value , nil until the flag is set unless a default is given
+	TimeKit *TimeKit
+	// Location is the location of the relative times and of the layouts without a zone ,
+	// time.Local if nil
+	Location *time.Location
+	// Layouts are tried in order , ParseLayouts if empty
+	Layouts []string
+}
+
+var _ flag.Getter = (*TimeFlag)(nil)
+
+// NewTimeFlag return a flag with a default value , which may be nil , in the given location
+func NewTimeFlag(value *TimeKit, location string) (*TimeFlag, error) {
+	l, err := loadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	return &TimeFlag{TimeKit: value, Location: l}, nil
+}
+
+// TimeFlagVar define a flag in the flag set and return it , e.g.
+//
+//	since := timkit.TimeFlagVar(flag.CommandLine, "since", "-7d", "start of the report")
+//
+// The default value is parsed like the command line and shown in the usage
+func TimeFlagVar(fs *flag.FlagSet, name, value, usage string) *TimeFlag {
+	f := &TimeFlag{}
+	if value != "" {
+		if err := f.Set(value); err != nil {
+			panic(fmt.Sprintf("timkit: invalid default value of flag %s : %v", name, err))
+		}
+	}
+	fs.Var(f, name, usage)
+	return f
+}
+
+// String return the value in its format , empty if the flag is not set
+func (f *TimeFlag) String() string {
+	if f == nil || f.TimeKit == nil {
+		return ""
+	}
+	return f.TimeKit.String()
+}
+
+// Set parse the value of the flag
+func (f *TimeFlag) Set(value string) error {
+	l := f.Location
+	if l == nil {
+		l = time.Local
+	}
+	layouts := f.Layouts
+	if len(layouts) == 0 {
+		layouts = ParseLayouts
+	}
+	t, err := parseInput(value, l, layouts)
+	if err != nil {
+		return err
+	}
+	f.TimeKit = NewTimeKit(t)
+	return nil
+}
+
+// Type return the name of the type in the usage of pflag
+func (f *TimeFlag) Type() string {
+	return "time"
+}
+
+// Get return the *TimeKit value
+func (f *TimeFlag) Get() interface{} {
+	return f.TimeKit
+}
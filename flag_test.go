@@ -0,0 +1,52 @@
+package timkit
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestTimeFlag(t *testing.T) {
+	SetClock(NewFakeClock(time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)))
+	defer SetClock(nil)
+
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	until, err := NewTimeFlag(nil, "Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.Var(until, "until", "end of the report")
+	since := TimeFlagVar(fs, "since", "-7d", "start of the report")
+	at := TimeFlagVar(fs, "at", "", "unix time")
+
+	if until.String() != "" || until.Get().(*TimeKit) != nil {
+		t.Errorf("until = %+v ,expected an unset flag", until)
+	}
+	if err := fs.Parse([]string{"-until", "today", "-at", "1609599845"}); err != nil {
+		t.Fatal(err)
+	}
+	if s := since.TimeKit.Time; !s.Equal(time.Date(2020, 12, 26, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("since = %+v ,expected 7 days ago", s)
+	}
+	if u := until.String(); u != "2021-01-02 00:00:00" || until.TimeKit.Location().String() != "Asia/Shanghai" {
+		t.Errorf("until = %+v ,expected today in Asia/Shanghai", u)
+	}
+	if at.TimeKit.Unix() != 1609599845 {
+		t.Errorf("at = %+v ,expected %+v", at.TimeKit.Unix(), 1609599845)
+	}
+
+	if err := until.Set("2021-02-03 04:05"); err != nil || until.String() != "2021-02-03 04:05:00" || until.TimeKit.Location().String() != "Asia/Shanghai" {
+		t.Errorf("Set = %+v %v ,expected 2021-02-03 04:05:00 in Asia/Shanghai", until, err)
+	}
+	if err := until.Set("2021-02-03T04:05:06Z"); err != nil || !until.TimeKit.Equal(time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)) {
+		t.Errorf("Set = %+v %v ,expected 2021-02-03T04:05:06Z", until, err)
+	}
+	if err := fs.Parse([]string{"-since", "sometime"}); err == nil {
+		t.Errorf("Parse ,expected an error")
+	}
+	if until.Type() != "time" {
+		t.Errorf("Type = %+v ,expected time", until.Type())
+	}
+}
@@ -0,0 +1,69 @@
+package timkit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// TransitionFormat is the format of WriteTransitions
+type TransitionFormat int
+
+const (
+	// TransitionText is a table aligned with spaces
+	TransitionText TransitionFormat = iota
+	// TransitionCSV is a CSV file with a header
+	TransitionCSV
+)
+
+// transitionHeader are the columns of WriteTransitions
+var transitionHeader = []string{"utc", "local", "from", "to", "offset", "dst", "shift"}
+
+// ZoneHistory return the transitions of the location from the start of fromYear to the end of
+// toYear in UTC , e.g. to show the offset of a region on a historic date . The local mean time
+// before the first transition has the name LMT in most locations
+func ZoneHistory(location string, fromYear, toYear int) ([]Transition, error) {
+	l, err := loadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	from := NewTimeKit(time.Date(fromYear, time.January, 1, 0, 0, 0, 0, time.UTC).In(l))
+	to := NewTimeKit(time.Date(toYear+1, time.January, 1, 0, 0, 0, 0, time.UTC).In(l))
+	return Transitions(from, to), nil
+}
+
+// WriteTransitions write the transitions as a table with the columns utc , local , from , to ,
+// offset , dst and shift , where dst is the daylight saving time flag of the time zone data , e.g.
+//
+//	utc                   local                from  to    offset  dst    shift
+//	2021-03-14T07:00:00Z  2021-03-14 03:00:00  EST   EDT   -04:00  true   +01:00
+func WriteTransitions(w io.Writer, transitions []Transition, format TransitionFormat) error {
+	rows := make([][]string, 0, len(transitions)+1)
+	rows = append(rows, transitionHeader)
+	for _, t := range transitions {
+		rows = append(rows, []string{
+			t.At.UTC().Format(time.RFC3339),
+			t.At.Format(DefaultFormat),
+			t.FromName,
+			t.ToName,
+			formatOffset(t.ToOffset),
+			strconv.FormatBool(t.IsDST),
+			formatOffset(int(t.Shift() / time.Second)),
+		})
+	}
+
+	if format == TransitionCSV {
+		return csv.NewWriter(w).WriteAll(rows)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
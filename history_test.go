@@ -0,0 +1,62 @@
+package timkit
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestZoneHistory(t *testing.T) {
+	transitions, err := ZoneHistory("America/New_York", 2020, 2021)
+	if err != nil || len(transitions) != 4 {
+		t.Fatalf("ZoneHistory = %d %v ,expected 4", len(transitions), err)
+	}
+	first := transitions[0]
+	if first.At.UTC().Format("2006-01-02 15:04") != "2020-03-08 07:00" || first.ToName != "EDT" ||
+		first.ToOffset != -4*3600 || !first.IsDST {
+		t.Errorf("ZoneHistory[0] = %s %s %d %v ,expected 2020-03-08 07:00 EDT -14400 true",
+			first.At.UTC(), first.ToName, first.ToOffset, first.IsDST)
+	}
+	if transitions[1].IsDST || transitions[1].ToName != "EST" {
+		t.Errorf("ZoneHistory[1] = %s %v ,expected EST false", transitions[1].ToName, transitions[1].IsDST)
+	}
+
+	// China observed daylight saving time from 1986 to 1991
+	transitions, err = ZoneHistory("Asia/Shanghai", 1980, 2021)
+	if err != nil || len(transitions) != 12 {
+		t.Errorf("ZoneHistory(Asia/Shanghai) = %d %v ,expected 12", len(transitions), err)
+	}
+	// the permanent move from +08:30 to +09:00 is not a daylight saving time
+	transitions, err = ZoneHistory("Asia/Pyongyang", 2018, 2018)
+	if err != nil || len(transitions) != 1 || transitions[0].IsDST || transitions[0].Shift() != 30*time.Minute {
+		t.Errorf("ZoneHistory(Asia/Pyongyang) = %+v %v ,expected one transition without dst", transitions, err)
+	}
+	if _, err := ZoneHistory("Nowhere/City", 2020, 2021); err == nil {
+		t.Errorf("ZoneHistory(Nowhere/City) ,expected an error")
+	}
+}
+
+func TestWriteTransitions(t *testing.T) {
+	transitions, _ := ZoneHistory("Europe/Berlin", 2021, 2021)
+
+	var b bytes.Buffer
+	if err := WriteTransitions(&b, transitions, TransitionCSV); err != nil {
+		t.Fatalf("WriteTransitions error = %v", err)
+	}
+	expected := "utc,local,from,to,offset,dst,shift\n" +
+		"2021-03-28T01:00:00Z,2021-03-28 03:00:00,CET,CEST,+02:00,true,+01:00\n" +
+		"2021-10-31T01:00:00Z,2021-10-31 02:00:00,CEST,CET,+01:00,false,-01:00\n"
+	if b.String() != expected {
+		t.Errorf("WriteTransitions(CSV) = %q ,expected %q", b.String(), expected)
+	}
+
+	b.Reset()
+	if err := WriteTransitions(&b, transitions, TransitionText); err != nil {
+		t.Fatalf("WriteTransitions error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "2021-03-28T01:00:00Z  2021-03-28 03:00:00  CET   CEST  +02:00") {
+		t.Errorf("WriteTransitions(Text) = %q", b.String())
+	}
+}
@@ -0,0 +1,230 @@
+package timkit
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidInterval = errors.New("timkit: invalid interval")
+
+// Interval is an amount of calendar time and elapsed time , e.g. "3d12h" or "P1M" .
+// Years , months and days keep the wall clock when added , the Duration is elapsed time
+type Interval struct {
+	Years    int
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// intervalUnits are the units of the short form , the others are those of time.ParseDuration
+var intervalUnits = map[string]Unit{
+	"y":  UnitYear,
+	"mo": UnitMonth,
+	"w":  UnitWeek,
+	"d":  UnitDay,
+}
+
+// ParseInterval parse an interval in the short form , e.g. "3d12h" , "1y6mo" , "-2w" or "90m" ,
+// or in the ISO 8601 form , e.g. "P1M" , "PT36H" , "P1Y2M3DT4H5M6.5S" or "-P1W"
+func ParseInterval(s string) (Interval, error) {
+	s = strings.TrimSpace(s)
+	value, sign := s, 1
+	if strings.HasPrefix(value, "-") {
+		value, sign = value[1:], -1
+	} else if strings.HasPrefix(value, "+") {
+		value = value[1:]
+	}
+
+	var i Interval
+	var err error
+	if strings.HasPrefix(value, "P") {
+		i, err = parseISOInterval(value[1:])
+	} else {
+		i, err = parseShortInterval(value)
+	}
+	if err != nil {
+		return Interval{}, err
+	}
+	if sign < 0 {
+		i = i.Negate()
+	}
+	return i, nil
+}
+
+func parseShortInterval(s string) (Interval, error) {
+	var i Interval
+	if s == "" {
+		return i, ErrInvalidInterval
+	}
+	for s != "" {
+		n := 0
+		for n < len(s) && ('0' <= s[n] && s[n] <= '9' || s[n] == '.') {
+			n++
+		}
+		u := n
+		for u < len(s) && (s[u] < '0' || s[u] > '9') && s[u] != '.' {
+			u++
+		}
+		amount, unit := s[:n], s[n:u]
+		if calendar, ok := intervalUnits[unit]; ok {
+			v, err := strconv.Atoi(amount)
+			if err != nil {
+				return Interval{}, ErrInvalidInterval
+			}
+			i.add(calendar, v)
+		} else {
+			d, err := time.ParseDuration(amount + unit)
+			if err != nil || amount == "" {
+				return Interval{}, ErrInvalidInterval
+			}
+			i.Duration += d
+		}
+		s = s[u:]
+	}
+	return i, nil
+}
+
+// parseISOInterval parse an ISO 8601 duration after the leading P
+func parseISOInterval(s string) (Interval, error) {
+	var i Interval
+	date, clock := s, ""
+	if t := strings.Index(s, "T"); t >= 0 {
+		date, clock = s[:t], s[t+1:]
+		if clock == "" {
+			return i, ErrInvalidInterval
+		}
+	}
+	if date == "" && clock == "" {
+		return i, ErrInvalidInterval
+	}
+
+	for date != "" {
+		n := strings.IndexAny(date, "YMWD")
+		if n <= 0 {
+			return Interval{}, ErrInvalidInterval
+		}
+		v, err := strconv.Atoi(date[:n])
+		if err != nil {
+			return Interval{}, ErrInvalidInterval
+		}
+		i.add(map[byte]Unit{'Y': UnitYear, 'M': UnitMonth, 'W': UnitWeek, 'D': UnitDay}[date[n]], v)
+		date = date[n+1:]
+	}
+	for clock != "" {
+		n := strings.IndexAny(clock, "HMS")
+		if n <= 0 {
+			return Interval{}, ErrInvalidInterval
+		}
+		d, err := time.ParseDuration(clock[:n] + strings.ToLower(clock[n:n+1]))
+		if err != nil {
+			return Interval{}, ErrInvalidInterval
+		}
+		i.Duration += d
+		clock = clock[n+1:]
+	}
+	return i, nil
+}
+
+func (i *Interval) add(u Unit, v int) {
+	switch u {
+	case UnitYear:
+		i.Years += v
+	case UnitMonth:
+		i.Months += v
+	case UnitWeek:
+		i.Days += v * daysPerWeek
+	case UnitDay:
+		i.Days += v
+	}
+}
+
+// IsZero whether the interval is empty
+func (i Interval) IsZero() bool {
+	return i == Interval{}
+}
+
+// Negate return the opposite interval
+func (i Interval) Negate() Interval {
+	return Interval{Years: -i.Years, Months: -i.Months, Days: -i.Days, Duration: -i.Duration}
+}
+
+// String return the interval in the ISO 8601 form , e.g. "P1Y2M3DT4H5M6S" , "PT0S" for the zero interval .
+// A part whose sign differs from the others is written with its own sign
+func (i Interval) String() string {
+	if i.IsZero() {
+		return "PT0S"
+	}
+	neg := i.Years <= 0 && i.Months <= 0 && i.Days <= 0 && i.Duration <= 0
+	if neg {
+		i = i.Negate()
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	b.WriteByte('P')
+	for _, part := range []struct {
+		v          int
+		designator byte
+	}{{i.Years, 'Y'}, {i.Months, 'M'}, {i.Days, 'D'}} {
+		if part.v != 0 {
+			b.WriteString(strconv.Itoa(part.v))
+			b.WriteByte(part.designator)
+		}
+	}
+	if d := i.Duration; d != 0 {
+		b.WriteByte('T')
+		h := d / time.Hour
+		d -= h * time.Hour
+		m := d / time.Minute
+		d -= m * time.Minute
+		if h != 0 {
+			b.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+		}
+		if m != 0 {
+			b.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+		}
+		if d != 0 {
+			b.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	return b.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface with the ISO 8601 form
+func (i Interval) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface , so an interval is decoded
+// from JSON , YAML and TOML strings
+func (i *Interval) UnmarshalText(data []byte) error {
+	v, err := ParseInterval(string(data))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// AddInterval add an interval to the current time , months do not overflow
+func (tk *TimeKit) AddInterval(i Interval) *TimeKit {
+	if i.Years != 0 || i.Months != 0 {
+		tk.AddMonthsNoOverflow(i.Years*monthsPerYear + i.Months)
+	}
+	if i.Days != 0 {
+		tk.AddDays(i.Days)
+	}
+	if i.Duration != 0 {
+		tk.SetTime(tk.Add(i.Duration))
+	}
+	return tk
+}
+
+// SubInterval remove an interval from the current time
+func (tk *TimeKit) SubInterval(i Interval) *TimeKit {
+	return tk.AddInterval(i.Negate())
+}
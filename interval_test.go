@@ -0,0 +1,68 @@
+package timkit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected Interval
+		iso      string
+	}{
+		{"3d12h", Interval{Days: 3, Duration: 12 * time.Hour}, "P3DT12H"},
+		{"1y6mo", Interval{Years: 1, Months: 6}, "P1Y6M"},
+		{"-2w", Interval{Days: -14}, "-P14D"},
+		{"1.5h", Interval{Duration: 90 * time.Minute}, "PT1H30M"},
+		{"P1M", Interval{Months: 1}, "P1M"},
+		{"PT36H", Interval{Duration: 36 * time.Hour}, "PT36H"},
+		{"P1Y2M3DT4H5M6.5S", Interval{1, 2, 3, 4*time.Hour + 5*time.Minute + 6500*time.Millisecond}, "P1Y2M3DT4H5M6.5S"},
+		{"-P1W", Interval{Days: -7}, "-P7D"},
+		{"P1DT-1H", Interval{Days: 1, Duration: -time.Hour}, "P1DT-1H"},
+		{"0s", Interval{}, "PT0S"},
+	}
+	for _, test := range tests {
+		i, err := ParseInterval(test.s)
+		if err != nil {
+			t.Errorf("ParseInterval(%q) error %v", test.s, err)
+			continue
+		}
+		if i != test.expected || i.String() != test.iso {
+			t.Errorf("ParseInterval(%q) = %+v %s ,expected %+v %s", test.s, i, i, test.expected, test.iso)
+		}
+		if back, err := ParseInterval(i.String()); err != nil || back != i {
+			t.Errorf("ParseInterval(%q) = %+v ,expected %+v", i.String(), back, i)
+		}
+	}
+
+	for _, s := range []string{"", "P", "PT", "3x", "1.5d", "P1H", "h", "1h-30m"} {
+		if _, err := ParseInterval(s); err == nil {
+			t.Errorf("ParseInterval(%q) ,expected an error", s)
+		}
+	}
+}
+
+func TestTimeKit_AddInterval(t *testing.T) {
+	tk := NewTimeKit(time.Date(2021, 1, 31, 15, 4, 5, 0, time.UTC))
+	tk.AddInterval(Interval{Months: 1, Days: 1, Duration: time.Hour})
+	if tk.String() != "2021-03-01 16:04:05" {
+		t.Errorf("AddInterval = %+v ,expected %+v", tk, "2021-03-01 16:04:05")
+	}
+	tk.SubInterval(Interval{Years: 1})
+	if tk.String() != "2020-03-01 16:04:05" {
+		t.Errorf("SubInterval = %+v ,expected %+v", tk, "2020-03-01 16:04:05")
+	}
+
+	var config struct {
+		Every Interval
+	}
+	if err := json.Unmarshal([]byte(`{"Every": "3d12h"}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(config)
+	if string(data) != `{"Every":"P3DT12H"}` {
+		t.Errorf("Marshal = %s ,expected %s", data, `{"Every":"P3DT12H"}`)
+	}
+}
@@ -0,0 +1,106 @@
+package timkit
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// KeyEncoding is the encoding of a sort key
+type KeyEncoding int
+
+const (
+	// KeyBinary is 12 bytes , the big-endian seconds with the sign bit flipped then the nanoseconds
+	KeyBinary KeyEncoding = iota
+	// KeyHex is the lowercase hex of KeyBinary , 24 characters
+	KeyHex
+	// KeyBase32 is the unpadded base32hex of KeyBinary , 20 characters
+	KeyBase32
+)
+
+const keySize = 12
+
+var ErrInvalidKey = errors.New("timkit: invalid sort key")
+
+// keyBase32 keep the byte order since its alphabet is sorted
+var keyBase32 = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// size return the length of an encoded key
+func (enc KeyEncoding) size() int {
+	switch enc {
+	case KeyHex:
+		return hex.EncodedLen(keySize)
+	case KeyBase32:
+		return keyBase32.EncodedLen(keySize)
+	}
+	return keySize
+}
+
+// SortKey return a fixed width key whose byte order is the chronological order .
+// Only the instant is encoded , so keys of different locations and negative years sort too
+func (tk *TimeKit) SortKey(enc KeyEncoding) []byte {
+	return sortKey(tk.Time, enc)
+}
+
+func sortKey(t time.Time, enc KeyEncoding) []byte {
+	key := make([]byte, keySize)
+	binary.BigEndian.PutUint64(key, uint64(t.Unix())^1<<63)
+	binary.BigEndian.PutUint32(key[8:], uint32(t.Nanosecond()))
+	switch enc {
+	case KeyHex:
+		out := make([]byte, enc.size())
+		hex.Encode(out, key)
+		return out
+	case KeyBase32:
+		out := make([]byte, enc.size())
+		keyBase32.Encode(out, key)
+		return out
+	}
+	return key
+}
+
+// ParseSortKey return the instant of a sort key in the given location .
+// The key may be followed by a suffix , e.g. in a composite key
+func ParseSortKey(key []byte, enc KeyEncoding, location string) (*TimeKit, error) {
+	l, err := loadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) < enc.size() {
+		return nil, ErrInvalidKey
+	}
+	key = key[:enc.size()]
+	raw := make([]byte, keySize)
+	switch enc {
+	case KeyHex:
+		_, err = hex.Decode(raw, key)
+	case KeyBase32:
+		_, err = keyBase32.Decode(raw, key)
+	default:
+		copy(raw, key)
+	}
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	nsec := binary.BigEndian.Uint32(raw[8:])
+	if nsec >= uint32(time.Second) {
+		return nil, ErrInvalidKey
+	}
+	sec := int64(binary.BigEndian.Uint64(raw) ^ 1<<63)
+	return NewTimeKit(time.Unix(sec, int64(nsec)).In(l)), nil
+}
+
+// SortKeyRange return the bounds of the keys of the instants in [from, to) ,
+// including the keys followed by a suffix : lower <= key < upper
+func SortKeyRange(from, to *TimeKit, enc KeyEncoding) (lower, upper []byte) {
+	return sortKey(from.Time, enc), sortKey(to.Time, enc)
+}
+
+// DaySortKeyRange return the bounds of the keys of the local day of tk ,
+// from the first instant of the day to the first instant of the next day
+func (tk *TimeKit) DaySortKeyRange(enc KeyEncoding) (lower, upper []byte) {
+	start := startOfDate(tk.Year(), tk.Month(), tk.Day(), tk.Location())
+	return sortKey(start, enc), sortKey(addUnits(start, UnitDay, 1), enc)
+}
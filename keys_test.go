@@ -0,0 +1,81 @@
+package timkit
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestTimeKit_SortKey(t *testing.T) {
+	l, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	times := []time.Time{
+		time.Date(-500, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 1, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 0, l),
+		time.Date(2021, 1, 2, 15, 4, 5, 0, l),
+		time.Date(2021, 1, 2, 21, 4, 5, 0, time.UTC),
+	}
+
+	for _, enc := range []KeyEncoding{KeyBinary, KeyHex, KeyBase32} {
+		keys := make([][]byte, len(times))
+		for i, tm := range times {
+			keys[i] = NewTimeKit(tm).SortKey(enc)
+		}
+		if !sort.SliceIsSorted(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i], keys[j]) < 0
+		}) {
+			t.Errorf("keys of encoding %d are not sorted : %s", enc, keys)
+		}
+		for i, key := range keys {
+			decoded, err := ParseSortKey(append(key, "/suffix"...), enc, "UTC")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !decoded.Equal(times[i]) {
+				t.Errorf("ParseSortKey = %+v ,expected %+v", decoded.Time, times[i])
+			}
+		}
+	}
+}
+
+func TestTimeKit_DaySortKeyRange(t *testing.T) {
+	tk := NewTimeKit(time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC))
+	lower, upper := tk.DaySortKeyRange(KeyHex)
+
+	inside := NewTimeKit(time.Date(2021, 1, 2, 23, 59, 59, 500, time.UTC)).SortKey(KeyHex)
+	outside := NewTimeKit(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)).SortKey(KeyHex)
+	if bytes.Compare(inside, lower) < 0 || bytes.Compare(inside, upper) >= 0 {
+		t.Errorf("key %s is not in [%s, %s)", inside, lower, upper)
+	}
+	if bytes.Compare(outside, upper) < 0 {
+		t.Errorf("key %s is in [%s, %s)", outside, lower, upper)
+	}
+}
+
+func TestTimeKit_DaySortKeyRange_SkippedMidnight(t *testing.T) {
+	// the clock moved from 00:00 to 01:00 in Sao Paulo on 2018-11-04
+	l, err := LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	nextDay := NewTimeKit(time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC)).SortKey(KeyHex)
+
+	lower, upper := NewTimeKit(time.Date(2018, 11, 3, 12, 0, 0, 0, l)).DaySortKeyRange(KeyHex)
+	lastHour := NewTimeKit(time.Date(2018, 11, 3, 23, 30, 0, 0, l)).SortKey(KeyHex)
+	if bytes.Compare(lastHour, lower) < 0 || bytes.Compare(lastHour, upper) >= 0 {
+		t.Errorf("key %s is not in [%s, %s)", lastHour, lower, upper)
+	}
+	if !bytes.Equal(upper, nextDay) {
+		t.Errorf("upper = %s ,expected %s", upper, nextDay)
+	}
+
+	lower, _ = NewTimeKit(time.Date(2018, 11, 4, 12, 0, 0, 0, l)).DaySortKeyRange(KeyHex)
+	if !bytes.Equal(lower, nextDay) {
+		t.Errorf("lower = %s ,expected %s", lower, nextDay)
+	}
+}
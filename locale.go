@@ -0,0 +1,194 @@
+package timkit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLocale is the locale used when none is given
+const DefaultLocale = "en"
+
+var ErrUnknownLocale = errors.New("timkit: unknown locale")
+
+// Locale is the translation of the names of months and weekdays and of the humanized differences
+type Locale struct {
+	Months        [12]string
+	ShortMonths   [12]string
+	Weekdays      [7]string
+	ShortWeekdays [7]string
+	// JustNow is used for a difference below a minute
+	JustNow string
+	// Past and Future wrap an amount , e.g. "%s ago"
+	Past   string
+	Future string
+	// Units are the singular and plural formats of an amount of a unit , e.g. "%d day"
+	Units map[Unit][2]string
+}
+
+var (
+	localesLock sync.RWMutex
+	locales     = map[string]*Locale{
+		"en": {
+			Months: [12]string{"January", "February", "March", "April", "May", "June",
+				"July", "August", "September", "October", "November", "December"},
+			ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+			Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+			ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+			JustNow:       "just now",
+			Past:          "%s ago",
+			Future:        "%s from now",
+			Units: map[Unit][2]string{
+				UnitMinute: {"%d minute", "%d minutes"},
+				UnitHour:   {"%d hour", "%d hours"},
+				UnitDay:    {"%d day", "%d days"},
+				UnitWeek:   {"%d week", "%d weeks"},
+				UnitMonth:  {"%d month", "%d months"},
+				UnitYear:   {"%d year", "%d years"},
+			},
+		},
+		"zh": {
+			Months: [12]string{"一月", "二月", "三月", "四月", "五月", "六月",
+				"七月", "八月", "九月", "十月", "十一月", "十二月"},
+			ShortMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			Weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+			ShortWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+			JustNow:       "刚刚",
+			Past:          "%s前",
+			Future:        "%s后",
+			Units: map[Unit][2]string{
+				UnitMinute: {"%d分钟", "%d分钟"},
+				UnitHour:   {"%d小时", "%d小时"},
+				UnitDay:    {"%d天", "%d天"},
+				UnitWeek:   {"%d周", "%d周"},
+				UnitMonth:  {"%d个月", "%d个月"},
+				UnitYear:   {"%d年", "%d年"},
+			},
+		},
+	}
+)
+
+// RegisterLocale add or replace a locale
+func RegisterLocale(name string, l *Locale) {
+	localesLock.Lock()
+	defer localesLock.Unlock()
+	locales[name] = l
+}
+
+// LookupLocale return the locale registered with the name
+func LookupLocale(name string) (*Locale, error) {
+	localesLock.RLock()
+	defer localesLock.RUnlock()
+	if l, ok := locales[name]; ok {
+		return l, nil
+	}
+	return nil, ErrUnknownLocale
+}
+
+// FormatLocale format the time like Format , the names of months and weekdays are translated
+func (tk *TimeKit) FormatLocale(layout, locale string) (string, error) {
+	l, err := LookupLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	return l.format(tk.Time, layout), nil
+}
+
+// DiffForHumans return the difference from t in English , e.g. "3 days ago" .
+// If t is nil , the difference is from now
+func (tk *TimeKit) DiffForHumans(t *TimeKit) string {
+	s, _ := tk.DiffForHumansLocale(t, DefaultLocale)
+	return s
+}
+
+// DiffForHumansLocale return the difference from t in the given locale
+func (tk *TimeKit) DiffForHumansLocale(t *TimeKit, locale string) (string, error) {
+	l, err := LookupLocale(locale)
+	if err != nil {
+		return "", err
+	}
+	return l.humanize(tk.DiffInSeconds(t, false)), nil
+}
+
+// format split the layout around the names of months and weekdays ,
+// following the rules of time.Format to recognize them
+func (l *Locale) format(t time.Time, layout string) string {
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(layout); i++ {
+		name, n := l.name(t, layout[i:])
+		if n == 0 {
+			continue
+		}
+		b.WriteString(t.Format(layout[start:i]))
+		b.WriteString(name)
+		i += n - 1
+		start = i + 1
+	}
+	b.WriteString(t.Format(layout[start:]))
+	return b.String()
+}
+
+// name return the translated name at the beginning of the layout and its length in the layout
+func (l *Locale) name(t time.Time, layout string) (string, int) {
+	switch {
+	case strings.HasPrefix(layout, "January"):
+		return l.Months[t.Month()-1], 7
+	case strings.HasPrefix(layout, "Jan") && !startsWithLower(layout[3:]):
+		return l.ShortMonths[t.Month()-1], 3
+	case strings.HasPrefix(layout, "Monday"):
+		return l.Weekdays[t.Weekday()], 6
+	case strings.HasPrefix(layout, "Mon") && !startsWithLower(layout[3:]):
+		return l.ShortWeekdays[t.Weekday()], 3
+	}
+	return "", 0
+}
+
+func startsWithLower(s string) bool {
+	return len(s) > 0 && 'a' <= s[0] && s[0] <= 'z'
+}
+
+// humanize return the text of a difference in seconds , positive in the past
+func (l *Locale) humanize(seconds int64) string {
+	wrap := l.Past
+	if seconds < 0 {
+		seconds = -seconds
+		wrap = l.Future
+	}
+
+	const (
+		minute = secondsPerMinute
+		hour   = minute * minutesPerHour
+		day    = hour * hoursPerDay
+		week   = day * daysPerWeek
+		month  = day * 30
+		year   = day * daysInNormalYear
+	)
+	var u Unit
+	var n int64
+	switch {
+	case seconds < minute:
+		return l.JustNow
+	case seconds < hour:
+		u, n = UnitMinute, seconds/minute
+	case seconds < day:
+		u, n = UnitHour, seconds/hour
+	case seconds < week:
+		u, n = UnitDay, seconds/day
+	case seconds < month:
+		u, n = UnitWeek, seconds/week
+	case seconds < year:
+		u, n = UnitMonth, seconds/month
+	default:
+		u, n = UnitYear, seconds/year
+	}
+
+	forms := l.Units[u]
+	f := forms[1]
+	if n == 1 {
+		f = forms[0]
+	}
+	return fmt.Sprintf(wrap, fmt.Sprintf(f, n))
+}
@@ -0,0 +1,56 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeKit_FormatLocale(t *testing.T) {
+	tk := NewTimeKit(time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC))
+	tests := []struct {
+		layout, locale, expected string
+	}{
+		{"Monday, January 2 2006", "en", "Saturday, January 2 2021"},
+		{"Mon Jan _2 15:04 MST", "zh", "周六 1月  2 15:04 UTC"},
+		{"2006年January2日 Monday", "zh", "2021年一月2日 星期六"},
+		{"Janet Monty", "zh", "Janet Monty"},
+	}
+	for _, test := range tests {
+		s, err := tk.FormatLocale(test.layout, test.locale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != test.expected {
+			t.Errorf("FormatLocale(%q, %q) = %+v ,expected %+v", test.layout, test.locale, s, test.expected)
+		}
+	}
+	if _, err := tk.FormatLocale(DateFormat, "xx"); err != ErrUnknownLocale {
+		t.Errorf("FormatLocale error = %+v ,expected %+v", err, ErrUnknownLocale)
+	}
+}
+
+func TestTimeKit_DiffForHumans(t *testing.T) {
+	base := NewTimeKit(time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC))
+	tests := []struct {
+		d        time.Duration
+		expected string
+		zh       string
+	}{
+		{-30 * time.Second, "just now", "刚刚"},
+		{-time.Minute, "1 minute ago", "1分钟前"},
+		{-3 * time.Hour, "3 hours ago", "3小时前"},
+		{50 * time.Hour, "2 days from now", "2天后"},
+		{-15 * 24 * time.Hour, "2 weeks ago", "2周前"},
+		{-70 * 24 * time.Hour, "2 months ago", "2个月前"},
+		{400 * 24 * time.Hour, "1 year from now", "1年后"},
+	}
+	for _, test := range tests {
+		tk := NewTimeKit(base.Add(test.d))
+		if s := tk.DiffForHumans(base); s != test.expected {
+			t.Errorf("DiffForHumans(%v) = %+v ,expected %+v", test.d, s, test.expected)
+		}
+		if s, _ := tk.DiffForHumansLocale(base, "zh"); s != test.zh {
+			t.Errorf("DiffForHumansLocale(%v) = %+v ,expected %+v", test.d, s, test.zh)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package timkit
+
+import (
+	"archive/zip"
+	"container/list"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrUnknownLocation = errors.New("timkit: unknown time zone")
+
+// LocationProvider load a location by its IANA name , e.g. "Asia/Shanghai" .
+// All the functions of the package taking a location name use the package provider
+type LocationProvider interface {
+	Location(name string) (*time.Location, error)
+}
+
+// LocationProviderFunc adapt an ordinary function to a LocationProvider
+type LocationProviderFunc func(name string) (*time.Location, error)
+
+// Location call f(name)
+func (f LocationProviderFunc) Location(name string) (*time.Location, error) {
+	return f(name)
+}
+
+var (
+	providerLock sync.RWMutex
+	provider     = defaultLocationProvider()
+)
+
+// defaultLocationProvider return the cached fixed offsets and system provider , falling back to the POSIX TZ strings
+func defaultLocationProvider() LocationProvider {
+	return NewCachedLocationProvider(ChainLocationProvider{FixedOffsetLocationProvider, SystemLocationProvider, POSIXLocationProvider})
+}
+
+// SetLocationProvider replace the provider of the package , usually wrapped by NewCachedLocationProvider .
+// A nil provider restores the default provider
+func SetLocationProvider(p LocationProvider) {
+	if p == nil {
+		p = defaultLocationProvider()
+	}
+	providerLock.Lock()
+	defer providerLock.Unlock()
+	provider = p
+}
+
+// GetLocationProvider return the provider of the package
+func GetLocationProvider() LocationProvider {
+	providerLock.RLock()
+	defer providerLock.RUnlock()
+	return provider
+}
+
+// LoadLocation return the location of the name using the provider of the package
+func LoadLocation(name string) (*time.Location, error) {
+	return loadLocation(name)
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	return GetLocationProvider().Location(name)
+}
+
+// PreloadLocations load the locations with the provider of the package , so a cached provider
+// has them before the first use . It return the first error but load all the names
+func PreloadLocations(names ...string) error {
+	return preload(GetLocationProvider(), names)
+}
+
+// SystemLocationProvider load the locations with time.LoadLocation , from the system zoneinfo ,
+// $ZONEINFO or the copy embedded when building with the tag `timkit_tzdata`
+var SystemLocationProvider LocationProvider = LocationProviderFunc(time.LoadLocation)
+
+// builtinLocation return the locations known without a source , as time.LoadLocation
+func builtinLocation(name string) (*time.Location, bool) {
+	switch name {
+	case "", "UTC":
+		return time.UTC, true
+	case "Local":
+		return time.Local, true
+	}
+	return nil, false
+}
+
+// validLocationName reject the names escaping a directory , as time.LoadLocation
+func validLocationName(name string) bool {
+	return name != "" && !strings.Contains(name, "..") && name[0] != '/' && name[0] != '\\'
+}
+
+func unknownLocation(name string) error {
+	return fmt.Errorf("%w %s", ErrUnknownLocation, name)
+}
+
+// DefaultLocationCacheSize is the number of locations kept by a CachedLocationProvider ,
+// more than the IANA names so the names given by untrusted inputs can not grow the cache without limit
+const DefaultLocationCacheSize = 1024
+
+// CachedLocationProvider keep the locations loaded by a source , it is safe for concurrent use .
+// The failures are not cached , and the least recently used location is evicted when the cache is full
+type CachedLocationProvider struct {
+	source LocationProvider
+	size   int
+	lock   sync.Mutex
+	cache  map[string]*list.Element
+	// recent hold the cachedLocation from the most to the least recently used
+	recent *list.List
+}
+
+type cachedLocation struct {
+	name     string
+	location *time.Location
+}
+
+// CachedLocationOption configure a CachedLocationProvider
+type CachedLocationOption func(p *CachedLocationProvider)
+
+// CachedLocationOptionSetSize set the number of locations kept , DefaultLocationCacheSize if n is not positive
+func CachedLocationOptionSetSize(n int) CachedLocationOption {
+	return func(p *CachedLocationProvider) {
+		p.size = n
+	}
+}
+
+// NewCachedLocationProvider return a provider caching the locations of the source
+func NewCachedLocationProvider(source LocationProvider, opt ...CachedLocationOption) *CachedLocationProvider {
+	p := &CachedLocationProvider{source: source, cache: make(map[string]*list.Element), recent: list.New()}
+	for _, o := range opt {
+		o(p)
+	}
+	if p.size <= 0 {
+		p.size = DefaultLocationCacheSize
+	}
+	return p
+}
+
+// Location return the cached location , loading it from the source the first time
+func (p *CachedLocationProvider) Location(name string) (*time.Location, error) {
+	p.lock.Lock()
+	if e, ok := p.cache[name]; ok {
+		p.recent.MoveToFront(e)
+		p.lock.Unlock()
+		return e.Value.(*cachedLocation).location, nil
+	}
+	p.lock.Unlock()
+
+	l, err := p.source.Location(name)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if e, ok := p.cache[name]; ok {
+		p.recent.MoveToFront(e)
+		return e.Value.(*cachedLocation).location, nil
+	}
+	p.cache[name] = p.recent.PushFront(&cachedLocation{name: name, location: l})
+	if p.recent.Len() > p.size {
+		last := p.recent.Back()
+		p.recent.Remove(last)
+		delete(p.cache, last.Value.(*cachedLocation).name)
+	}
+	return l, nil
+}
+
+// Len return the number of locations in the cache
+func (p *CachedLocationProvider) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.recent.Len()
+}
+
+// Preload load the locations into the cache , e.g. during initialization
+func (p *CachedLocationProvider) Preload(names ...string) error {
+	return preload(p, names)
+}
+
+func preload(p LocationProvider, names []string) error {
+	var first error
+	for _, name := range names {
+		if _, err := p.Location(name); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Purge remove all the locations from the cache
+func (p *CachedLocationProvider) Purge() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.cache = make(map[string]*list.Element)
+	p.recent.Init()
+}
+
+// DirLocationProvider load the locations from the TZif files of a zoneinfo directory ,
+// e.g. "/usr/share/zoneinfo"
+type DirLocationProvider string
+
+// Location read the file of the name in the directory
+func (dir DirLocationProvider) Location(name string) (*time.Location, error) {
+	if l, ok := builtinLocation(name); ok {
+		return l, nil
+	}
+	if !validLocationName(name) {
+		return nil, unknownLocation(name)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(string(dir), filepath.FromSlash(name)))
+	if err != nil {
+		return nil, unknownLocation(name)
+	}
+	return time.LoadLocationFromTZData(name, data)
+}
+
+// ZipLocationProvider load the locations from a zip of TZif files ,
+// such as $GOROOT/lib/time/zoneinfo.zip . The archive is read into memory once
+type ZipLocationProvider struct {
+	files map[string][]byte
+}
+
+// NewZipLocationProvider read the TZif files of the zip at the path
+func NewZipLocationProvider(path string) (*ZipLocationProvider, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	p := &ZipLocationProvider{files: make(map[string][]byte, len(r.File))}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+		p.files[f.Name] = data
+	}
+	return p, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(rc)
+}
+
+// Location parse the TZif file of the name in the archive
+func (p *ZipLocationProvider) Location(name string) (*time.Location, error) {
+	if l, ok := builtinLocation(name); ok {
+		return l, nil
+	}
+	data, ok := p.files[name]
+	if !ok {
+		return nil, unknownLocation(name)
+	}
+	return time.LoadLocationFromTZData(name, data)
+}
+
+// MapLocationProvider is an in-memory set of locations by name
+type MapLocationProvider map[string]*time.Location
+
+// Location return the location of the name in the map
+func (m MapLocationProvider) Location(name string) (*time.Location, error) {
+	if l, ok := m[name]; ok {
+		return l, nil
+	}
+	if l, ok := builtinLocation(name); ok {
+		return l, nil
+	}
+	return nil, unknownLocation(name)
+}
+
+// ChainLocationProvider try the providers in order and return the first location found , e.g.
+//
+//	timkit.ChainLocationProvider{timkit.MapLocationProvider{...}, timkit.SystemLocationProvider}
+type ChainLocationProvider []LocationProvider
+
+// Location return the location of the first provider knowing the name
+func (c ChainLocationProvider) Location(name string) (*time.Location, error) {
+	err := unknownLocation(name)
+	for _, p := range c {
+		l, e := p.Location(name)
+		if e == nil {
+			return l, nil
+		}
+		err = e
+	}
+	return nil, err
+}
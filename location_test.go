@@ -0,0 +1,146 @@
+package timkit
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachedLocationProvider(t *testing.T) {
+	var calls int32
+	source := LocationProviderFunc(func(name string) (*time.Location, error) {
+		atomic.AddInt32(&calls, 1)
+		return SystemLocationProvider.Location(name)
+	})
+	p := NewCachedLocationProvider(source)
+	if err := p.Preload("Asia/Shanghai", "Nowhere/City", "UTC"); err == nil {
+		t.Errorf("Preload ,expected an error")
+	}
+	for i := 0; i < 10; i++ {
+		if l, err := p.Location("Asia/Shanghai"); err != nil || l.String() != "Asia/Shanghai" {
+			t.Fatalf("Location = %+v %v ,expected Asia/Shanghai", l, err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("calls = %+v ,expected %+v", calls, 3)
+	}
+	p.Purge()
+	p.Location("Asia/Shanghai")
+	if calls != 4 {
+		t.Errorf("calls = %+v ,expected %+v", calls, 4)
+	}
+}
+
+func TestCachedLocationProvider_Size(t *testing.T) {
+	var calls int32
+	source := LocationProviderFunc(func(name string) (*time.Location, error) {
+		atomic.AddInt32(&calls, 1)
+		return FixedOffsetLocationProvider.Location(name)
+	})
+	p := NewCachedLocationProvider(source, CachedLocationOptionSetSize(2))
+	p.Location("+01:00")
+	p.Location("+02:00")
+	p.Location("+01:00")
+	// +02:00 is the least recently used
+	p.Location("+03:00")
+	if n := p.Len(); n != 2 {
+		t.Errorf("Len = %+v ,expected %+v", n, 2)
+	}
+	p.Location("+01:00")
+	if calls != 3 {
+		t.Errorf("calls = %+v ,expected %+v", calls, 3)
+	}
+	p.Location("+02:00")
+	if calls != 4 {
+		t.Errorf("calls = %+v ,expected %+v", calls, 4)
+	}
+
+	// the offsets given by untrusted inputs do not grow the default cache without limit
+	p = NewCachedLocationProvider(FixedOffsetLocationProvider)
+	for _, prefix := range []string{"UTC", "GMT", ""} {
+		for i := 0; i < 13*60; i++ {
+			p.Location(fmt.Sprintf("%s+%02d:%02d", prefix, i/60, i%60))
+		}
+	}
+	if n := p.Len(); n != DefaultLocationCacheSize {
+		t.Errorf("Len = %+v ,expected %+v", n, DefaultLocationCacheSize)
+	}
+}
+
+func TestSetLocationProvider(t *testing.T) {
+	office := time.FixedZone("Office", 5*60*60+30*60)
+	SetLocationProvider(ChainLocationProvider{MapLocationProvider{"Office": office}, SystemLocationProvider})
+	defer SetLocationProvider(nil)
+
+	tk, err := Parse(DefaultFormat, "2021-01-02 15:04:05", "Office")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Location() != office || tk.Unix() != 1609580045 {
+		t.Errorf("Parse = %+v ,expected %+v", tk.Time, "2021-01-02 15:04:05 +0530")
+	}
+	if _, err := CreateFromTimestamp(0, "UTC"); err != nil {
+		t.Errorf("CreateFromTimestamp error %v", err)
+	}
+	if _, err := NowWithLocation("Nowhere/City"); err == nil {
+		t.Errorf("NowWithLocation ,expected an error")
+	}
+
+	SetLocationProvider(MapLocationProvider{})
+	if _, err := LoadLocation("Asia/Shanghai"); !errors.Is(err, ErrUnknownLocation) {
+		t.Errorf("LoadLocation error = %v ,expected %v", err, ErrUnknownLocation)
+	}
+	if l, err := LoadLocation(""); err != nil || l != time.UTC {
+		t.Errorf("LoadLocation = %+v %v ,expected UTC", l, err)
+	}
+}
+
+func TestDirLocationProvider(t *testing.T) {
+	p := DirLocationProvider("/usr/share/zoneinfo")
+	l, err := p.Location("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+	if _, offset := time.Date(2021, 1, 2, 0, 0, 0, 0, l).Zone(); l.String() != "Asia/Shanghai" || offset != 8*60*60 {
+		t.Errorf("Location = %+v %+v ,expected Asia/Shanghai +08:00", l, offset)
+	}
+	for _, name := range []string{"../zoneinfo/UTC", "/etc/passwd", "Nowhere/City"} {
+		if _, err := p.Location(name); !errors.Is(err, ErrUnknownLocation) {
+			t.Errorf("Location(%q) error = %v ,expected %v", name, err, ErrUnknownLocation)
+		}
+	}
+}
+
+func TestZipLocationProvider(t *testing.T) {
+	p, err := NewZipLocationProvider(filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"))
+	if err != nil {
+		t.Skip(err)
+	}
+	l, err := p.Location("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, offset := time.Date(2021, 7, 2, 0, 0, 0, 0, l).Zone(); offset != -4*60*60 {
+		t.Errorf("offset = %+v ,expected %+v", offset, -4*60*60)
+	}
+	if _, err := p.Location("Nowhere/City"); !errors.Is(err, ErrUnknownLocation) {
+		t.Errorf("Location error = %v ,expected %v", err, ErrUnknownLocation)
+	}
+}
+
+func BenchmarkLoadLocation(b *testing.B) {
+	b.Run("system", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			SystemLocationProvider.Location("America/New_York")
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			LoadLocation("America/New_York")
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package timkit
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidTransition = errors.New("timkit: invalid location transition")
+
+// maxTZifZones is the number of zones a TZif file can index
+const maxTZifZones = 256
+
+// LocationBuilder assemble a location from chosen transitions , e.g. to test the handling of
+// daylight saving time deterministically :
+//
+//	l, err := timkit.NewLocationBuilder("Test/Jump", 3600, "TST").
+//		Transition(time.Date(2021, 6, 2, 1, 0, 0, 0, time.UTC), 7200, "TDT", true).
+//		Build()
+//
+// The location can be given by name to the package with a provider , e.g.
+//
+//	timkit.SetLocationProvider(timkit.ChainLocationProvider{
+//		timkit.MapLocationProvider{l.String(): l}, timkit.GetLocationProvider()})
+type LocationBuilder struct {
+	name        string
+	zones       []tzifZone
+	transitions []tzifTransition
+	rule        string
+	err         error
+}
+
+// NewLocationBuilder return a builder of the location named name , whose zone before the first
+// transition has the offset in seconds east of UTC and the abbreviation
+func NewLocationBuilder(name string, offset int, abbreviation string) *LocationBuilder {
+	return &LocationBuilder{name: name, zones: []tzifZone{{offset: offset, name: abbreviation}}}
+}
+
+// Transition add a transition at the instant to the zone of the offset in seconds east of UTC and
+// the abbreviation . The transitions are added in increasing order of their instants , which are
+// whole seconds
+func (b *LocationBuilder) Transition(at time.Time, offset int, abbreviation string, isDST bool) *LocationBuilder {
+	if b.err != nil {
+		return b
+	}
+	when := at.Unix()
+	if n := len(b.transitions); n > 0 && when <= b.transitions[n-1].when || at.Nanosecond() != 0 {
+		b.err = ErrInvalidTransition
+		return b
+	}
+
+	zone := tzifZone{offset: offset, isDST: isDST, name: abbreviation}
+	index := -1
+	for i, z := range b.zones {
+		if z == zone {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		if len(b.zones) == maxTZifZones {
+			b.err = ErrInvalidTransition
+			return b
+		}
+		index = len(b.zones)
+		b.zones = append(b.zones, zone)
+	}
+	b.transitions = append(b.transitions, tzifTransition{when: when, zone: index})
+	return b
+}
+
+// Rule set the POSIX TZ string followed after the last transition , see LoadPOSIXLocation .
+// Without a rule the zone of the last transition is kept forever
+func (b *LocationBuilder) Rule(tz string) *LocationBuilder {
+	if b.err != nil {
+		return b
+	}
+	if _, err := parsePOSIXTZ(tz); err != nil {
+		b.err = err
+		return b
+	}
+	b.rule = tz
+	return b
+}
+
+// Build return the location , or the first error of the builder
+func (b *LocationBuilder) Build() (*time.Location, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return time.LoadLocationFromTZData(b.name, encodeTZif(b.zones, b.transitions, b.rule))
+}
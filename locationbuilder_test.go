@@ -0,0 +1,90 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocationBuilder(t *testing.T) {
+	jump := time.Date(2021, 6, 2, 1, 0, 0, 0, time.UTC)
+	back := time.Date(2021, 6, 9, 0, 0, 0, 0, time.UTC)
+	l, err := NewLocationBuilder("Test/Jump", 3600, "TST").
+		Transition(jump, 7200, "TDT", true).
+		Transition(back, 3600, "TST", false).
+		Build()
+	if err != nil {
+		t.Fatalf("Build error = %v", err)
+	}
+	for _, c := range []struct {
+		at       time.Time
+		expected string
+	}{
+		{time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), "2000-01-01 01:00:00 +0100 TST"},
+		{jump.Add(-time.Second), "2021-06-02 01:59:59 +0100 TST"},
+		{jump, "2021-06-02 03:00:00 +0200 TDT"},
+		{back, "2021-06-09 01:00:00 +0100 TST"},
+		{time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), "2100-01-01 01:00:00 +0100 TST"},
+	} {
+		if got := c.at.In(l).String(); got != c.expected {
+			t.Errorf("In(Test/Jump) = %s ,expected %s", got, c.expected)
+		}
+	}
+
+	// the location is loaded by name through a provider
+	prev := GetLocationProvider()
+	defer SetLocationProvider(prev)
+	SetLocationProvider(ChainLocationProvider{MapLocationProvider{l.String(): l}, prev})
+
+	tk, err := Create(2021, time.June, 2, 2, 30, 0, 0, "Test/Jump", OptionSetWallTimePolicy(WallTimeError))
+	if err != ErrSkippedWallTime {
+		t.Errorf("Create error = %v ,expected %v", err, ErrSkippedWallTime)
+	}
+	tk, err = Create(2021, time.June, 1, 12, 0, 0, 0, "Test/Jump")
+	if err != nil {
+		t.Fatalf("Create error = %v", err)
+	}
+	if h := tk.Copy().AddDays(1).HoursInDay(); h != 23 {
+		t.Errorf("HoursInDay = %+v ,expected %+v", h, 23)
+	}
+	if d := tk.Copy().AddDays(1).Time.String(); d != "2021-06-02 12:00:00 +0200 TDT" {
+		t.Errorf("AddDays = %s ,expected 2021-06-02 12:00:00 +0200 TDT", d)
+	}
+	if days := tk.DiffInDays(tk.Copy().AddDays(1), false); days != 1 {
+		t.Errorf("DiffInDays = %+v ,expected %+v", days, 1)
+	}
+	if next, ok := tk.NextTransition(); !ok || !next.At.Equal(jump) || !next.IsDST || next.ToName != "TDT" {
+		t.Errorf("NextTransition = %s %v ,expected %s", next.At, ok, jump)
+	}
+	if s := tk.Copy().AddDays(1).StartOfDay().Time.String(); s != "2021-06-02 00:00:00 +0100 TST" {
+		t.Errorf("StartOfDay = %s ,expected 2021-06-02 00:00:00 +0100 TST", s)
+	}
+}
+
+func TestLocationBuilder_Rule(t *testing.T) {
+	l, err := NewLocationBuilder("Test/Rule", 8*3600, "CST").
+		Transition(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), -5*3600, "EST", false).
+		Rule("EST5EDT,M3.2.0,M11.1.0").
+		Build()
+	if err != nil {
+		t.Fatalf("Build error = %v", err)
+	}
+	if got := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC).In(l).Format("-07:00 MST"); got != "-04:00 EDT" {
+		t.Errorf("In(Test/Rule) = %s ,expected -04:00 EDT", got)
+	}
+	if got := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC).In(l).Format("-07:00 MST"); got != "+08:00 CST" {
+		t.Errorf("In(Test/Rule) = %s ,expected +08:00 CST", got)
+	}
+
+	at := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, b := range []*LocationBuilder{
+		NewLocationBuilder("Test/Bad", 0, "UTC").Transition(at, 3600, "A", false).Transition(at, 0, "B", false),
+		NewLocationBuilder("Test/Bad", 0, "UTC").Transition(at.Add(time.Millisecond), 3600, "A", false),
+	} {
+		if _, err := b.Build(); err != ErrInvalidTransition {
+			t.Errorf("Build error = %v ,expected %v", err, ErrInvalidTransition)
+		}
+	}
+	if _, err := NewLocationBuilder("Test/Bad", 0, "UTC").Rule("bad").Build(); err != ErrInvalidPOSIXTZ {
+		t.Errorf("Build error = %v ,expected %v", err, ErrInvalidPOSIXTZ)
+	}
+}
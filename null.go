@@ -0,0 +1,118 @@
+package timkit
+
+import (
+	"bytes"
+	"database/sql/driver"
+)
+
+// NullTimeKit is a TimeKit which may be null , e.g. an optional deleted_at .
+// TimeKit is only meaningful when Valid is true
+type NullTimeKit struct {
+	TimeKit *TimeKit
+	Valid   bool
+}
+
+// NewNullTimeKit return a NullTimeKit valid when tk is not nil
+func NewNullTimeKit(tk *TimeKit) NullTimeKit {
+	return NullTimeKit{TimeKit: tk, Valid: tk != nil}
+}
+
+// IsNull whether the value is null
+func (n NullTimeKit) IsNull() bool {
+	return !n.Valid || n.TimeKit == nil
+}
+
+// Ptr return the TimeKit , or nil when the value is null
+func (n NullTimeKit) Ptr() *TimeKit {
+	if n.IsNull() {
+		return nil
+	}
+	return n.TimeKit
+}
+
+// String return the time with the format of the TimeKit , or an empty string when null
+func (n NullTimeKit) String() string {
+	if n.IsNull() {
+		return ""
+	}
+	return n.TimeKit.String()
+}
+
+// Format return the time formatted with layout , or an empty string when null
+func (n NullTimeKit) Format(layout string) string {
+	if n.IsNull() {
+		return ""
+	}
+	return n.TimeKit.Format(layout)
+}
+
+// target return the TimeKit to decode into , allocating it when needed
+func (n *NullTimeKit) target() *TimeKit {
+	if n.TimeKit == nil {
+		n.TimeKit = new(TimeKit)
+	}
+	return n.TimeKit
+}
+
+// MarshalJSON implements the json.Marshaler interface , null is written as null
+func (n NullTimeKit) MarshalJSON() ([]byte, error) {
+	if n.IsNull() {
+		return []byte("null"), nil
+	}
+	return n.TimeKit.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (n *NullTimeKit) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		n.Valid = false
+		return nil
+	}
+	if err := n.target().UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface , null is written as an empty text
+func (n NullTimeKit) MarshalText() ([]byte, error) {
+	if n.IsNull() {
+		return []byte{}, nil
+	}
+	return n.TimeKit.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface , an empty text is null
+func (n *NullTimeKit) UnmarshalText(data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		n.Valid = false
+		return nil
+	}
+	if err := n.target().UnmarshalText(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface , null is stored as NULL
+func (n NullTimeKit) Value() (driver.Value, error) {
+	if n.IsNull() {
+		return nil, nil
+	}
+	return n.TimeKit.Value()
+}
+
+// Scan implements the sql.Scanner interface , NULL is scanned as null
+func (n *NullTimeKit) Scan(src interface{}) error {
+	if src == nil {
+		n.Valid = false
+		return nil
+	}
+	if err := n.target().Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
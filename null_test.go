@@ -0,0 +1,62 @@
+package timkit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNullTimeKit_JSON(t *testing.T) {
+	type record struct {
+		DeletedAt NullTimeKit `json:"deleted_at"`
+	}
+
+	data, err := json.Marshal(record{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"deleted_at":null}` {
+		t.Errorf("MarshalJSON = %s ,expected %s", data, `{"deleted_at":null}`)
+	}
+
+	tm := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	data, err = json.Marshal(record{NewNullTimeKit(NewTimeKit(tm))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"deleted_at":"2021-01-02 15:04:05"}` {
+		t.Errorf("MarshalJSON = %s ,expected %s", data, `{"deleted_at":"2021-01-02 15:04:05"}`)
+	}
+
+	var r record
+	if err := json.Unmarshal([]byte(`{"deleted_at":"2021-01-02T15:04:05Z"}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if !r.DeletedAt.Valid || !r.DeletedAt.TimeKit.Equal(tm) {
+		t.Errorf("UnmarshalJSON = %+v ,expected %+v", r.DeletedAt.Ptr(), tm)
+	}
+	if err := json.Unmarshal([]byte(`{"deleted_at":null}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.DeletedAt.Valid || r.DeletedAt.String() != "" {
+		t.Errorf("UnmarshalJSON(null) = %+v ,expected null", r.DeletedAt.Ptr())
+	}
+}
+
+func TestNullTimeKit_SQL(t *testing.T) {
+	var n NullTimeKit
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("Scan(nil) = %+v %+v ,expected null", n.Ptr(), err)
+	}
+	if v, err := n.Value(); v != nil || err != nil {
+		t.Errorf("Value = %+v %+v ,expected nil", v, err)
+	}
+
+	tm := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	if err := n.Scan(tm); err != nil || !n.Valid {
+		t.Fatalf("Scan = %+v ,expected valid", err)
+	}
+	if v, err := n.Value(); err != nil || v != tm {
+		t.Errorf("Value = %+v %+v ,expected %+v", v, err, tm)
+	}
+}
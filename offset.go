@@ -0,0 +1,97 @@
+package timkit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrInvalidOffset = errors.New("timkit: invalid UTC offset")
+
+// maxOffsetHours is the bound of the hours of an offset , as in ISO 8601
+const maxOffsetHours = 23
+
+// fixedZones keep the fixed zones by offset , so an offset is always the same location
+var fixedZones sync.Map
+
+// FixedZone return the location of a fixed offset in seconds east of UTC , named by its offset
+// such as "+08:00" or "-03:30" so the name can be loaded again . The zero offset is UTC
+func FixedZone(offset int) *time.Location {
+	if offset == 0 {
+		return time.UTC
+	}
+	if l, ok := fixedZones.Load(offset); ok {
+		return l.(*time.Location)
+	}
+	name := formatOffset(offset)
+	if seconds := offset % secondsPerMinute; seconds != 0 {
+		if seconds < 0 {
+			seconds = -seconds
+		}
+		name = fmt.Sprintf("%s:%02d", name, seconds)
+	}
+	l, _ := fixedZones.LoadOrStore(offset, time.FixedZone(name, offset))
+	return l.(*time.Location)
+}
+
+// ParseOffset return the offset in seconds east of UTC of a specification such as "+08:00" ,
+// "-0330" , "+8" , "UTC+8" , "GMT-5" or "Z" . Unlike the POSIX TZ strings , the sign of
+// "UTC+8" and "GMT-5" is east of UTC
+func ParseOffset(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "Z" || s == "z" {
+		return 0, nil
+	}
+	if len(s) > 3 && (strings.EqualFold(s[:3], "UTC") || strings.EqualFold(s[:3], "GMT")) {
+		s = s[3:]
+	}
+	if s == "" || s[0] != '+' && s[0] != '-' {
+		return 0, ErrInvalidOffset
+	}
+
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+	s = s[1:]
+	// the compact forms hhmm and hhmmss
+	if !strings.Contains(s, ":") && (len(s) == 4 || len(s) == 6) {
+		compact := s[:2] + ":" + s[2:4]
+		if len(s) == 6 {
+			compact += ":" + s[4:]
+		}
+		s = compact
+	}
+
+	hours, s, ok := posixNumber(s, 0, maxOffsetHours)
+	if !ok {
+		return 0, ErrInvalidOffset
+	}
+	offset := hours * minutesPerHour * secondsPerMinute
+	for _, unit := range []int{secondsPerMinute, 1} {
+		if s == "" {
+			break
+		}
+		if len(s) < 3 || s[0] != ':' || !isDigit(s[1]) || !isDigit(s[2]) || s[1] > '5' {
+			return 0, ErrInvalidOffset
+		}
+		offset += (int(s[1]-'0')*10 + int(s[2]-'0')) * unit
+		s = s[3:]
+	}
+	if s != "" {
+		return 0, ErrInvalidOffset
+	}
+	return sign * offset, nil
+}
+
+// FixedOffsetLocationProvider load the names which are offsets , see ParseOffset , as the cached
+// locations of FixedZone . It is the first provider of the default provider of the package
+var FixedOffsetLocationProvider LocationProvider = LocationProviderFunc(func(name string) (*time.Location, error) {
+	offset, err := ParseOffset(name)
+	if err != nil {
+		return nil, unknownLocation(name)
+	}
+	return FixedZone(offset), nil
+})
@@ -0,0 +1,75 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOffset(t *testing.T) {
+	for _, c := range []struct {
+		value    string
+		expected int
+	}{
+		{"Z", 0},
+		{"+08:00", 8 * 3600},
+		{"-0330", -(3*3600 + 30*60)},
+		{"+8", 8 * 3600},
+		{"UTC+8", 8 * 3600},
+		{"utc-03:30", -(3*3600 + 30*60)},
+		{"GMT-5", -5 * 3600},
+		{"+00:19:32", 19*60 + 32},
+		{"-001932", -(19*60 + 32)},
+		{" +05:45 ", 5*3600 + 45*60},
+	} {
+		if got, err := ParseOffset(c.value); err != nil || got != c.expected {
+			t.Errorf("ParseOffset(%q) = %+v %v ,expected %+v", c.value, got, err, c.expected)
+		}
+	}
+	for _, value := range []string{"", "UTC", "8", "+24:00", "+08:60", "+08:0", "+08:00:", "+123", "UTC+8x", "Asia/Shanghai"} {
+		if _, err := ParseOffset(value); err != ErrInvalidOffset {
+			t.Errorf("ParseOffset(%q) error = %v ,expected %v", value, err, ErrInvalidOffset)
+		}
+	}
+}
+
+func TestFixedZone(t *testing.T) {
+	l := FixedZone(8 * 3600)
+	if l.String() != "+08:00" || FixedZone(8*3600) != l {
+		t.Errorf("FixedZone = %s ,expected the cached +08:00", l)
+	}
+	if FixedZone(0) != time.UTC {
+		t.Errorf("FixedZone(0) ,expected UTC")
+	}
+	if got := FixedZone(-(19*60 + 32)).String(); got != "-00:19:32" {
+		t.Errorf("FixedZone = %s ,expected -00:19:32", got)
+	}
+
+	// the name of the location is loaded again to the same location
+	for _, name := range []string{"+08:00", "UTC+8", "+0800", "GMT+08"} {
+		if got, err := LoadLocation(name); err != nil || got != l {
+			t.Errorf("LoadLocation(%s) = %s %v ,expected %s", name, got, err, l)
+		}
+	}
+	if got, _ := LoadLocation(l.String()); got != l {
+		t.Errorf("LoadLocation(%s) = %s ,expected %s", l, got, l)
+	}
+}
+
+func TestParse_FixedOffset(t *testing.T) {
+	tk, err := Parse(DefaultFormat, "2021-06-01 12:00:00", "GMT-5")
+	if err != nil || tk.Time.String() != "2021-06-01 12:00:00 -0500 -05:00" {
+		t.Errorf("Parse = %s %v ,expected 2021-06-01 12:00:00 -0500 -05:00", tk.Time, err)
+	}
+	tk, err = CreateFromTimestamp(1622548800, "+05:30")
+	if err != nil || tk.Format(time.RFC3339) != "2021-06-01T17:30:00+05:30" {
+		t.Errorf("CreateFromTimestamp = %s %v ,expected 2021-06-01T17:30:00+05:30", tk.Time, err)
+	}
+	if tk, err = CreateFromTimestamp(1622548800, "Z"); err != nil || tk.Location() != time.UTC {
+		t.Errorf("CreateFromTimestamp(Z) = %s %v ,expected UTC", tk.Time, err)
+	}
+
+	// a POSIX TZ string keeps its sign west of UTC
+	if l, err := LoadLocation("EST5"); err != nil || NewTimeKit(time.Unix(0, 0).In(l)).Offset() != -5*3600 {
+		t.Errorf("LoadLocation(EST5) = %s %v ,expected -05:00", l, err)
+	}
+}
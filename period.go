@@ -0,0 +1,120 @@
+package timkit
+
+import (
+	"sort"
+	"time"
+)
+
+// Period is the half-open range of time [Start, End)
+type Period struct {
+	Start *TimeKit
+	End   *TimeKit
+}
+
+// NewPeriod return a new Period from start to end
+func NewPeriod(start, end *TimeKit) Period {
+	return Period{Start: start, End: end}
+}
+
+// Duration return the elapsed time of the period
+func (p Period) Duration() time.Duration {
+	return p.End.Sub(p.Start.Time)
+}
+
+// IsEmpty whether the period contains no instant
+func (p Period) IsEmpty() bool {
+	return !p.End.After(p.Start.Time)
+}
+
+// Contains whether t is inside the period
+func (p Period) Contains(t *TimeKit) bool {
+	return !t.Before(p.Start.Time) && t.Before(p.End.Time)
+}
+
+// Overlaps whether the two periods share at least one instant
+func (p Period) Overlaps(o Period) bool {
+	return p.Start.Before(o.End.Time) && o.Start.Before(p.End.Time)
+}
+
+// String return the period as `start - end` using the format of its bounds
+func (p Period) String() string {
+	return p.Start.String() + " - " + p.End.String()
+}
+
+// span is the plain time version of a Period used by the computations
+type span struct {
+	start, end time.Time
+}
+
+func (s span) period(l *time.Location) Period {
+	return Period{Start: NewTimeKit(s.start.In(l)), End: NewTimeKit(s.end.In(l))}
+}
+
+// mergeSpans sort the spans and merge the ones overlapping or touching
+func mergeSpans(spans []span) []span {
+	if len(spans) == 0 {
+		return spans
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start.Before(spans[j].start)
+	})
+	merged := []span{spans[0]}
+	for _, s := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if s.start.After(last.end) {
+			merged = append(merged, s)
+			continue
+		}
+		if s.end.After(last.end) {
+			last.end = s.end
+		}
+	}
+	return merged
+}
+
+// intersectSpans return the instants in both merged span lists
+func intersectSpans(a, b []span) []span {
+	var out []span
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].start, a[i].end
+		if b[j].start.After(start) {
+			start = b[j].start
+		}
+		if b[j].end.Before(end) {
+			end = b[j].end
+		}
+		if start.Before(end) {
+			out = append(out, span{start, end})
+		}
+		if a[i].end.Before(b[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return out
+}
+
+// subtractSpans return the instants of the merged list a which are not in the merged list b
+func subtractSpans(a, b []span) []span {
+	var out []span
+	j := 0
+	for _, s := range a {
+		start := s.start
+		for j < len(b) && !b[j].end.After(start) {
+			j++
+		}
+		for k := j; k < len(b) && b[k].start.Before(s.end); k++ {
+			if b[k].start.After(start) {
+				out = append(out, span{start, b[k].start})
+			}
+			if b[k].end.After(start) {
+				start = b[k].end
+			}
+		}
+		if start.Before(s.end) {
+			out = append(out, span{start, s.end})
+		}
+	}
+	return out
+}
@@ -0,0 +1,185 @@
+package timkit
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidPOSIXTZ = errors.New("timkit: invalid POSIX TZ string")
+
+// posixTZ is a parsed POSIX TZ string , the offsets are in seconds east of UTC
+type posixTZ struct {
+	std       string
+	stdOffset int
+	dst       string
+	dstOffset int
+}
+
+// LoadPOSIXLocation return a location following the rules of a POSIX TZ string for any year ,
+// e.g. "CST6CDT,M3.2.0,M11.1.0" , "CET-1CEST,M3.5.0,M10.5.0/3" or "<+0530>-5:30" .
+// The offsets are west of UTC as in POSIX . Without a rule , the daylight saving time follows
+// the US rules "M3.2.0,M11.1.0" . The location is named by the string . The rules are read by
+// time.LoadLocationFromTZData from the footer of the TZif data , which needs Go 1.15
+func LoadPOSIXLocation(tz string) (*time.Location, error) {
+	p, err := parsePOSIXTZ(tz)
+	if err != nil {
+		return nil, err
+	}
+	zones := []tzifZone{{offset: p.stdOffset, name: p.std}}
+	if p.dst != "" {
+		zones = append(zones, tzifZone{offset: p.dstOffset, isDST: true, name: p.dst})
+	}
+	return time.LoadLocationFromTZData(tz, encodeTZif(zones, nil, tz))
+}
+
+// POSIXLocationProvider load the names which are POSIX TZ strings , it is chained after
+// SystemLocationProvider by the default provider of the package
+var POSIXLocationProvider LocationProvider = LocationProviderFunc(func(name string) (*time.Location, error) {
+	l, err := LoadPOSIXLocation(name)
+	if err != nil {
+		return nil, unknownLocation(name)
+	}
+	return l, nil
+})
+
+func parsePOSIXTZ(s string) (posixTZ, error) {
+	var p posixTZ
+	var ok bool
+	if p.std, s, ok = posixName(s); !ok {
+		return p, ErrInvalidPOSIXTZ
+	}
+	if p.stdOffset, s, ok = posixOffset(s, 24); !ok {
+		return p, ErrInvalidPOSIXTZ
+	}
+	p.stdOffset = -p.stdOffset
+	if s == "" {
+		return p, nil
+	}
+
+	if p.dst, s, ok = posixName(s); !ok {
+		return p, ErrInvalidPOSIXTZ
+	}
+	p.dstOffset = p.stdOffset + secondsPerMinute*minutesPerHour
+	if s != "" && s[0] != ',' {
+		if p.dstOffset, s, ok = posixOffset(s, 24); !ok {
+			return p, ErrInvalidPOSIXTZ
+		}
+		p.dstOffset = -p.dstOffset
+	}
+	if s == "" {
+		return p, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if s == "" || s[0] != ',' {
+			return p, ErrInvalidPOSIXTZ
+		}
+		if s, ok = posixRule(s[1:]); !ok {
+			return p, ErrInvalidPOSIXTZ
+		}
+	}
+	if s != "" {
+		return p, ErrInvalidPOSIXTZ
+	}
+	return p, nil
+}
+
+// posixName read a name of at least 3 letters , or of signs , digits and letters between < and >
+func posixName(s string) (string, string, bool) {
+	if s != "" && s[0] == '<' {
+		for i := 1; i < len(s); i++ {
+			c := s[i]
+			if c == '>' {
+				return s[1:i], s[i+1:], i >= 4
+			}
+			if !isLetter(c) && !isDigit(c) && c != '+' && c != '-' {
+				return "", s, false
+			}
+		}
+		return "", s, false
+	}
+	i := 0
+	for i < len(s) && isLetter(s[i]) {
+		i++
+	}
+	return s[:i], s[i:], i >= 3
+}
+
+// posixOffset read [+-]hh[:mm[:ss]] in seconds , the hours are at most maxHours
+func posixOffset(s string, maxHours int) (int, string, bool) {
+	sign := 1
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	hours, s, ok := posixNumber(s, 0, maxHours)
+	if !ok {
+		return 0, s, false
+	}
+	offset := hours * secondsPerMinute * minutesPerHour
+	for _, unit := range []int{secondsPerMinute, 1} {
+		if s == "" || s[0] != ':' {
+			break
+		}
+		var n int
+		if n, s, ok = posixNumber(s[1:], 0, 59); !ok {
+			return 0, s, false
+		}
+		offset += n * unit
+	}
+	return sign * offset, s, true
+}
+
+// posixRule read a date Jn , n or Mm.w.d and an optional /time
+func posixRule(s string) (string, bool) {
+	var ok bool
+	switch {
+	case s != "" && s[0] == 'J':
+		_, s, ok = posixNumber(s[1:], 1, 365)
+	case s != "" && s[0] == 'M':
+		s = s[1:]
+		for i, bounds := range [][2]int{{1, 12}, {1, 5}, {0, 6}} {
+			if _, s, ok = posixNumber(s, bounds[0], bounds[1]); !ok {
+				return s, false
+			}
+			if i < 2 {
+				if s == "" || s[0] != '.' {
+					return s, false
+				}
+				s = s[1:]
+			}
+		}
+	default:
+		_, s, ok = posixNumber(s, 0, 365)
+	}
+	if !ok {
+		return s, false
+	}
+	if s != "" && s[0] == '/' {
+		// the extension of RFC 8536 allow -167 to 167 hours
+		if _, s, ok = posixOffset(s[1:], 167); !ok {
+			return s, false
+		}
+	}
+	return s, true
+}
+
+// posixNumber read a decimal number in [lo, hi]
+func posixNumber(s string, lo, hi int) (int, string, bool) {
+	i, n := 0, 0
+	for i < len(s) && isDigit(s[i]) && n <= hi {
+		n = n*10 + int(s[i]-'0')
+		i++
+	}
+	return n, s[i:], i > 0 && lo <= n && n <= hi
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,66 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadPOSIXLocation(t *testing.T) {
+	tests := []struct {
+		tz, iana string
+	}{
+		{"CST6CDT,M3.2.0,M11.1.0", "America/Chicago"},
+		{"EST5EDT", "America/New_York"},
+		{"CET-1CEST,M3.5.0,M10.5.0/3", "Europe/Berlin"},
+		{"AEST-10AEDT,M10.1.0,M4.1.0/3", "Australia/Sydney"},
+		{"<+0530>-5:30", "Asia/Kolkata"},
+		{"<-03>3", "America/Sao_Paulo"},
+		{"NZST-12NZDT,M9.5.0,M4.1.0/3", "Pacific/Auckland"},
+	}
+	for _, test := range tests {
+		l, err := LoadPOSIXLocation(test.tz)
+		if err != nil {
+			t.Errorf("LoadPOSIXLocation(%q) error %v", test.tz, err)
+			continue
+		}
+		iana, err := time.LoadLocation(test.iana)
+		if err != nil {
+			t.Skip(err)
+		}
+		// compare each 6 hours of years in the past , now and in the future
+		for _, year := range []int{2021, 2022, 2037, 2100} {
+			for tm := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC); tm.Year() == year; tm = tm.Add(6 * time.Hour) {
+				name, offset := tm.In(l).Zone()
+				_, expected := tm.In(iana).Zone()
+				if offset != expected {
+					t.Errorf("%s at %v = %s %d ,expected %d as %s", test.tz, tm, name, offset, expected, test.iana)
+					break
+				}
+			}
+		}
+	}
+
+	l, _ := LoadPOSIXLocation("<+0530>-5:30")
+	if name, _ := time.Date(2021, 1, 2, 0, 0, 0, 0, l).Zone(); name != "+0530" || l.String() != "<+0530>-5:30" {
+		t.Errorf("Zone = %+v %+v ,expected +0530", name, l)
+	}
+
+	for _, tz := range []string{"", "UTC", "CST", "CST6CDT,M3.2.0", "CST6CDT,M13.2.0,M11.1.0", "CST6CDT,M3.2.0,M11.1.0,", "<+05>", "AB5", "CST25", "CET-1CEST,J366,J1"} {
+		if _, err := LoadPOSIXLocation(tz); err != ErrInvalidPOSIXTZ {
+			t.Errorf("LoadPOSIXLocation(%q) error = %v ,expected %v", tz, err, ErrInvalidPOSIXTZ)
+		}
+	}
+}
+
+func TestParse_POSIXLocation(t *testing.T) {
+	tk, err := Parse(DefaultFormat, "2021-07-02 15:04:05", "CST6CDT,M3.2.0,M11.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, offset := tk.Zone(); name != "CDT" || offset != -5*60*60 {
+		t.Errorf("Parse = %+v ,expected CDT -05:00", tk.Time)
+	}
+	if tk, err := CreateFromTimestamp(1609599845, "<+0530>-5:30"); err != nil || tk.String() != "2021-01-02 20:34:05" {
+		t.Errorf("CreateFromTimestamp = %+v %v ,expected %+v", tk, err, "2021-01-02 20:34:05")
+	}
+}
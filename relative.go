@@ -0,0 +1,154 @@
+package timkit
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRelative = errors.New("timkit: invalid relative time")
+
+// relativeUnits are the short units of the offsets , the long names are parsed by ParseUnit
+var relativeUnits = map[string]Unit{
+	"s":  UnitSecond,
+	"m":  UnitMinute,
+	"h":  UnitHour,
+	"d":  UnitDay,
+	"w":  UnitWeek,
+	"mo": UnitMonth,
+	"q":  UnitQuarter,
+	"y":  UnitYear,
+}
+
+// ParseRelative return the time of a relative expression from base , base is not modified .
+// An expression is an anchor followed by offsets , both optional :
+//
+//	now , today , yesterday , tomorrow , startOfWeek , endOfMonth , ...
+//	-7d , +1h30m , -1mo , +2y , today+9h , startOfWeek-1w
+//
+// Seconds , minutes and hours add elapsed time , the other units keep the wall clock
+// and months do not overflow . If base is nil it is the current time in local
+func ParseRelative(expr string, base *TimeKit) (*TimeKit, error) {
+	if base == nil {
+		base = Now()
+	}
+	s := strings.TrimSpace(expr)
+	if s == "" {
+		return nil, ErrInvalidRelative
+	}
+
+	anchor, offsets := s, ""
+	if i := strings.IndexAny(s, "+-"); i >= 0 {
+		anchor, offsets = strings.TrimSpace(s[:i]), s[i:]
+	}
+	tk := base.Copy()
+	if err := tk.applyAnchor(anchor); err != nil {
+		return nil, err
+	}
+
+	for offsets != "" {
+		sign := 1
+		if offsets[0] == '-' {
+			sign = -1
+		}
+		offsets = strings.TrimSpace(offsets[1:])
+		end := strings.IndexAny(offsets, "+-")
+		if end < 0 {
+			end = len(offsets)
+		}
+		if err := tk.applyOffsets(offsets[:end], sign); err != nil {
+			return nil, err
+		}
+		offsets = offsets[end:]
+	}
+	return tk, nil
+}
+
+// applyAnchor move the time to an anchor , the empty anchor is now . The days start at
+// their first instant , which is after midnight when the midnight is skipped
+func (tk *TimeKit) applyAnchor(anchor string) error {
+	name := strings.ToLower(anchor)
+	switch name {
+	case "", "now":
+	case "today":
+		tk.SetTime(startOfDate(tk.Year(), tk.Month(), tk.Day(), tk.Location()))
+	case "yesterday":
+		tk.SetTime(startOfDate(tk.Year(), tk.Month(), tk.Day()-1, tk.Location()))
+	case "tomorrow":
+		tk.SetTime(startOfDate(tk.Year(), tk.Month(), tk.Day()+1, tk.Location()))
+	default:
+		switch {
+		case strings.HasPrefix(name, "startof"):
+			u, err := ParseUnit(name[len("startof"):])
+			if err != nil {
+				return ErrInvalidRelative
+			}
+			tk.StartOf(u)
+		case strings.HasPrefix(name, "endof"):
+			u, err := ParseUnit(name[len("endof"):])
+			if err != nil {
+				return ErrInvalidRelative
+			}
+			tk.EndOf(u)
+		default:
+			return ErrInvalidRelative
+		}
+	}
+	return nil
+}
+
+// applyOffsets add a sequence of amounts and units such as "1h30m"
+func (tk *TimeKit) applyOffsets(s string, sign int) error {
+	if s == "" {
+		return ErrInvalidRelative
+	}
+	for s != "" {
+		i := 0
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		k := i
+		for k < len(s) && s[k] == ' ' {
+			k++
+		}
+		j := k
+		for j < len(s) && s[j] != ' ' && (s[j] < '0' || s[j] > '9') {
+			j++
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return ErrInvalidRelative
+		}
+		u, ok := relativeUnits[s[k:j]]
+		if !ok {
+			if u, err = ParseUnit(s[k:j]); err != nil {
+				return ErrInvalidRelative
+			}
+		}
+		tk.addOffset(u, sign*n)
+		s = strings.TrimSpace(s[j:])
+	}
+	return nil
+}
+
+func (tk *TimeKit) addOffset(u Unit, n int) {
+	switch u {
+	case UnitSecond:
+		tk.SetTime(tk.Add(time.Duration(n) * time.Second))
+	case UnitMinute:
+		tk.SetTime(tk.Add(time.Duration(n) * time.Minute))
+	case UnitHour:
+		tk.SetTime(tk.Add(time.Duration(n) * time.Hour))
+	case UnitDay:
+		tk.AddDays(n)
+	case UnitWeek:
+		tk.AddWeeks(n)
+	case UnitMonth:
+		tk.AddMonthsNoOverflow(n)
+	case UnitQuarter:
+		tk.AddMonthsNoOverflow(n * monthsPerQuarter)
+	case UnitYear:
+		tk.AddMonthsNoOverflow(n * monthsPerYear)
+	}
+}
@@ -0,0 +1,81 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelative(t *testing.T) {
+	l, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	base := NewTimeKit(time.Date(2021, 3, 31, 15, 4, 5, 0, l))
+	tests := []struct {
+		expr, expected string
+	}{
+		{"now", "2021-03-31 15:04:05"},
+		{"-7d", "2021-03-24 15:04:05"},
+		{"now-1h", "2021-03-31 14:04:05"},
+		{"+1h30m", "2021-03-31 16:34:05"},
+		{"yesterday", "2021-03-30 00:00:00"},
+		{"Tomorrow + 9h", "2021-04-01 09:00:00"},
+		{"startOfWeek", "2021-03-29 00:00:00"},
+		{"startOfMonth-1mo", "2021-02-01 00:00:00"},
+		{"-1mo", "2021-02-28 15:04:05"},
+		{"endOfDay-2 days+1s", "2021-03-30 00:00:00"},
+		{"-1y+2q", "2020-09-30 15:04:05"},
+		{"today-24h", "2021-03-30 00:00:00"},
+	}
+	for _, test := range tests {
+		tk, err := ParseRelative(test.expr, base)
+		if err != nil {
+			t.Errorf("ParseRelative(%q) error %v", test.expr, err)
+			continue
+		}
+		if tk.String() != test.expected || tk.Location() != l {
+			t.Errorf("ParseRelative(%q) = %+v ,expected %+v", test.expr, tk, test.expected)
+		}
+	}
+	if base.String() != "2021-03-31 15:04:05" {
+		t.Errorf("base = %+v ,expected it is not modified", base)
+	}
+
+	for _, expr := range []string{"", "2021-01-02", "-", "-100", "+1x", "startOfFortnight", "later"} {
+		if _, err := ParseRelative(expr, base); err != ErrInvalidRelative {
+			t.Errorf("ParseRelative(%q) error = %v ,expected %v", expr, err, ErrInvalidRelative)
+		}
+	}
+}
+
+func TestParseRelative_SkippedMidnight(t *testing.T) {
+	// the clock moved from 00:00 to 01:00 in Sao Paulo on 2018-11-04
+	l, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		base     time.Time
+		expr     string
+		expected string
+	}{
+		{time.Date(2018, 11, 3, 23, 30, 0, 0, l), "tomorrow", "2018-11-04 01:00:00 -0200 -02"},
+		{time.Date(2018, 11, 4, 12, 0, 0, 0, l), "today", "2018-11-04 01:00:00 -0200 -02"},
+		{time.Date(2018, 11, 4, 12, 0, 0, 0, l), "yesterday", "2018-11-03 00:00:00 -0300 -03"},
+		{time.Date(2018, 11, 5, 12, 0, 0, 0, l), "yesterday", "2018-11-04 01:00:00 -0200 -02"},
+	}
+	for _, test := range tests {
+		base := NewTimeKit(test.base)
+		tk, err := ParseRelative(test.expr, base)
+		if err != nil {
+			t.Errorf("ParseRelative(%q) error %v", test.expr, err)
+			continue
+		}
+		if tk.Time.String() != test.expected {
+			t.Errorf("ParseRelative(%q) = %+v ,expected %+v", test.expr, tk.Time, test.expected)
+		}
+		if test.expr == "tomorrow" && !tk.After(base.Time) {
+			t.Errorf("ParseRelative(%q) = %+v ,expected after %+v", test.expr, tk.Time, base.Time)
+		}
+	}
+}
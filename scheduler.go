@@ -0,0 +1,387 @@
+package timkit
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Schedule produce the activation times of a job
+type Schedule interface {
+	// Next return the first activation strictly after t, nil when there is none
+	Next(t *TimeKit) *TimeKit
+}
+
+// ScheduleFunc adapt an ordinary function to a Schedule
+type ScheduleFunc func(t *TimeKit) *TimeKit
+
+// Next call f(t)
+func (f ScheduleFunc) Next(t *TimeKit) *TimeKit {
+	return f(t)
+}
+
+// Every return a schedule activating every d
+func Every(d time.Duration) Schedule {
+	return ScheduleFunc(func(t *TimeKit) *TimeKit {
+		if d <= 0 {
+			return nil
+		}
+		next := t.Copy()
+		next.SetTime(t.Add(d))
+		return next
+	})
+}
+
+// Job is the function run by the scheduler
+type Job func(ctx context.Context) error
+
+// MissedRunPolicy decide what happens to the runs a job missed
+type MissedRunPolicy int
+
+const (
+	// MissedRunSkip drop the missed runs and wait for the next one
+	MissedRunSkip MissedRunPolicy = iota
+	// MissedRunOnce run the job once for all the missed runs
+	MissedRunOnce
+	// MissedRunAll run the job once for every missed run
+	MissedRunAll
+)
+
+// DefaultGracePeriod is how late a run may start before it counts as missed
+const DefaultGracePeriod = time.Second
+
+var (
+	ErrSchedulerStarted = errors.New("timkit: scheduler already started")
+	ErrJobExists        = errors.New("timkit: job already registered")
+	ErrInvalidJob       = errors.New("timkit: job needs a name, a schedule and a function")
+)
+
+// JobEvent describe a run of a job , it is passed to the scheduler hooks
+type JobEvent struct {
+	Name      string
+	Scheduled *TimeKit
+	Started   *TimeKit
+	Finished  *TimeKit
+	Err       error
+}
+
+type scheduledJob struct {
+	name         string
+	schedule     Schedule
+	fn           Job
+	location     *time.Location
+	jitter       time.Duration
+	grace        time.Duration
+	policy       MissedRunPolicy
+	allowOverlap bool
+	lastRun      time.Time
+
+	lock    sync.Mutex
+	running int
+}
+
+// JobOption configure a job when it is registered
+type JobOption func(j *scheduledJob)
+
+// JobOptionSetLocation evaluate the schedule of the job in the given location
+func JobOptionSetLocation(l *time.Location) JobOption {
+	return func(j *scheduledJob) {
+		j.location = l
+	}
+}
+
+// JobOptionSetJitter delay every run by a random duration in [0, jitter)
+func JobOptionSetJitter(jitter time.Duration) JobOption {
+	return func(j *scheduledJob) {
+		j.jitter = jitter
+	}
+}
+
+// JobOptionSetMissedRunPolicy set what happens to the missed runs
+func JobOptionSetMissedRunPolicy(p MissedRunPolicy) JobOption {
+	return func(j *scheduledJob) {
+		j.policy = p
+	}
+}
+
+// JobOptionSetGracePeriod set how late a run may start before it counts as missed
+func JobOptionSetGracePeriod(grace time.Duration) JobOption {
+	return func(j *scheduledJob) {
+		j.grace = grace
+	}
+}
+
+// JobOptionSetAllowOverlap allow a run to start while the previous one is still running
+func JobOptionSetAllowOverlap(allow bool) JobOption {
+	return func(j *scheduledJob) {
+		j.allowOverlap = allow
+	}
+}
+
+// JobOptionSetLastRun set when the job last ran , the runs scheduled since are missed runs
+func JobOptionSetLastRun(t time.Time) JobOption {
+	return func(j *scheduledJob) {
+		j.lastRun = t
+	}
+}
+
+// next return the activation following t in the location of the job
+func (j *scheduledJob) next(t time.Time) time.Time {
+	n := j.schedule.Next(NewTimeKit(t.In(j.location)))
+	if n == nil || !n.After(t) {
+		return time.Time{}
+	}
+	return n.Time
+}
+
+// acquire reserve a run slot , it fails when the previous run is still running
+func (j *scheduledJob) acquire() bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if j.running > 0 && !j.allowOverlap {
+		return false
+	}
+	j.running++
+	return true
+}
+
+func (j *scheduledJob) release() {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.running--
+}
+
+// Scheduler run registered jobs at the activations of their schedule
+type Scheduler struct {
+	clock    Clock
+	onStart  func(JobEvent)
+	onFinish func(JobEvent)
+
+	lock      sync.Mutex
+	rand      *rand.Rand
+	jobs      map[string]*scheduledJob
+	started   bool
+	loopCtx   context.Context
+	stopLoops context.CancelFunc
+	jobCtx    context.Context
+	stopJobs  context.CancelFunc
+	loops     sync.WaitGroup
+	runs      sync.WaitGroup
+}
+
+// SchedulerOption configure a Scheduler
+type SchedulerOption func(s *Scheduler)
+
+// SchedulerOptionSetClock drive the scheduler with the given clock instead of the package clock
+func SchedulerOptionSetClock(c Clock) SchedulerOption {
+	return func(s *Scheduler) {
+		s.clock = c
+	}
+}
+
+// SchedulerOptionSetOnStart set the hook called before every run
+func SchedulerOptionSetOnStart(f func(JobEvent)) SchedulerOption {
+	return func(s *Scheduler) {
+		s.onStart = f
+	}
+}
+
+// SchedulerOptionSetOnFinish set the hook called after every run
+func SchedulerOptionSetOnFinish(f func(JobEvent)) SchedulerOption {
+	return func(s *Scheduler) {
+		s.onFinish = f
+	}
+}
+
+// NewScheduler return a new stopped Scheduler
+func NewScheduler(opt ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		clock: GetClock(),
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+		jobs:  make(map[string]*scheduledJob),
+	}
+	for _, o := range opt {
+		o(s)
+	}
+	return s
+}
+
+// Register add a job to the scheduler .
+// A job registered on a started scheduler is scheduled right away
+func (s *Scheduler) Register(name string, schedule Schedule, fn Job, opt ...JobOption) error {
+	if name == "" || schedule == nil || fn == nil {
+		return ErrInvalidJob
+	}
+	j := &scheduledJob{
+		name:     name,
+		schedule: schedule,
+		fn:       fn,
+		location: time.Local,
+		grace:    DefaultGracePeriod,
+	}
+	for _, o := range opt {
+		o(j)
+	}
+	if j.location == nil {
+		j.location = time.Local
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.jobs[name]; ok {
+		return ErrJobExists
+	}
+	s.jobs[name] = j
+	if s.started {
+		s.loops.Add(1)
+		go s.loop(j)
+	}
+	return nil
+}
+
+// Start schedule the registered jobs and return immediately .
+// Cancelling ctx stops the scheduler and the running jobs
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.started {
+		return ErrSchedulerStarted
+	}
+	s.started = true
+	s.jobCtx, s.stopJobs = context.WithCancel(ctx)
+	s.loopCtx, s.stopLoops = context.WithCancel(s.jobCtx)
+	for _, j := range s.jobs {
+		s.loops.Add(1)
+		go s.loop(j)
+	}
+	return nil
+}
+
+// Stop stop scheduling new runs and wait for the running jobs to finish .
+// When ctx is done first the running jobs are cancelled and ctx.Err() is returned
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.lock.Lock()
+	if !s.started {
+		s.lock.Unlock()
+		return nil
+	}
+	s.started = false
+	s.stopLoops()
+	stopJobs := s.stopJobs
+	s.lock.Unlock()
+
+	s.loops.Wait()
+	done := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(done)
+	}()
+
+	defer stopJobs()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) jitter(j *scheduledJob) time.Duration {
+	if j.jitter <= 0 {
+		return 0
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return time.Duration(s.rand.Int63n(int64(j.jitter)))
+}
+
+// loop wait for the activations of a job until the scheduler stops
+func (s *Scheduler) loop(j *scheduledJob) {
+	defer s.loops.Done()
+
+	s.lock.Lock()
+	ctx := s.loopCtx
+	s.lock.Unlock()
+
+	last := j.lastRun
+	if last.IsZero() {
+		last = s.clock.Now()
+	}
+	for {
+		next := j.next(last)
+		if next.IsZero() {
+			return
+		}
+		delay := s.jitter(j)
+		timer := s.clock.NewTimer(next.Add(delay).Sub(s.clock.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+
+		current := s.clock.Now()
+		var missed, due []time.Time
+		for t := next; !t.IsZero() && !t.After(current); t = j.next(t) {
+			if current.Sub(t)-delay > j.grace {
+				missed = append(missed, t)
+			} else {
+				due = append(due, t)
+			}
+			last = t
+		}
+
+		switch j.policy {
+		case MissedRunOnce:
+			if len(missed) > 0 && len(due) == 0 {
+				due = missed[len(missed)-1:]
+			}
+		case MissedRunAll:
+			due = append(missed, due...)
+		}
+		if len(due) > 0 {
+			s.dispatch(j, due)
+		}
+	}
+}
+
+// dispatch run the job for the given activations one after the other
+func (s *Scheduler) dispatch(j *scheduledJob, scheduled []time.Time) {
+	if !j.acquire() {
+		return
+	}
+	s.lock.Lock()
+	ctx := s.jobCtx
+	s.lock.Unlock()
+
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		defer j.release()
+		for _, t := range scheduled {
+			if ctx.Err() != nil {
+				return
+			}
+			s.run(ctx, j, t)
+		}
+	}()
+}
+
+func (s *Scheduler) run(ctx context.Context, j *scheduledJob, scheduled time.Time) {
+	e := JobEvent{
+		Name:      j.name,
+		Scheduled: NewTimeKit(scheduled.In(j.location)),
+		Started:   NewTimeKit(s.clock.Now().In(j.location)),
+	}
+	if s.onStart != nil {
+		s.onStart(e)
+	}
+	e.Err = j.fn(ctx)
+	e.Finished = NewTimeKit(s.clock.Now().In(j.location))
+	if s.onFinish != nil {
+		s.onFinish(e)
+	}
+}
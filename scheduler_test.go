@@ -0,0 +1,146 @@
+package timkit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestScheduler(start time.Time) (*Scheduler, *FakeClock, chan JobEvent) {
+	c := NewFakeClock(start)
+	finished := make(chan JobEvent, 16)
+	s := NewScheduler(
+		SchedulerOptionSetClock(c),
+		SchedulerOptionSetOnFinish(func(e JobEvent) {
+			finished <- e
+		}),
+	)
+	return s, c, finished
+}
+
+func waitEvent(t *testing.T, events chan JobEvent) JobEvent {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatalf("no job event received")
+	}
+	return JobEvent{}
+}
+
+func TestScheduler_Run(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 0, 0, time.UTC)
+	s, c, finished := newTestScheduler(start)
+	err := s.Register("tick", Every(time.Minute), func(ctx context.Context) error {
+		return nil
+	}, JobOptionSetLocation(time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 2; i++ {
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+		e := waitEvent(t, finished)
+		expected := start.Add(time.Duration(i) * time.Minute)
+		if !e.Scheduled.Equal(expected) {
+			t.Errorf("Scheduled = %+v ,expected %+v", e.Scheduled, expected)
+		}
+	}
+
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("Stop = %+v ,expected nil", err)
+	}
+}
+
+func TestScheduler_MissedRunPolicy(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 0, 0, time.UTC)
+	cases := []struct {
+		policy   MissedRunPolicy
+		expected int
+	}{
+		{MissedRunSkip, 0},
+		{MissedRunOnce, 1},
+		{MissedRunAll, 3},
+	}
+
+	for _, c := range cases {
+		s, clock, finished := newTestScheduler(start)
+		s.Register("catch-up", Every(time.Minute), func(ctx context.Context) error {
+			return nil
+		}, JobOptionSetLastRun(start.Add(-3*time.Minute-5*time.Second)), JobOptionSetMissedRunPolicy(c.policy))
+		s.Start(context.Background())
+
+		// the loop waits for the next run once the missed ones are handled
+		clock.BlockUntil(1)
+		s.Stop(context.Background())
+		got := len(finished)
+		if got != c.expected {
+			t.Errorf("policy %d ran %d times ,expected %d", c.policy, got, c.expected)
+		}
+	}
+}
+
+func TestScheduler_Overlap(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 0, 0, time.UTC)
+	s, c, finished := newTestScheduler(start)
+	release := make(chan struct{})
+	s.Register("slow", Every(time.Minute), func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	s.Start(context.Background())
+
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+	c.BlockUntil(1)
+	close(release)
+
+	e := waitEvent(t, finished)
+	if !e.Scheduled.Equal(start.Add(time.Minute)) {
+		t.Errorf("Scheduled = %+v ,expected %+v", e.Scheduled, start.Add(time.Minute))
+	}
+	s.Stop(context.Background())
+	select {
+	case e := <-finished:
+		t.Errorf("overlapping run at %+v was not skipped", e.Scheduled)
+	default:
+	}
+}
+
+func TestScheduler_StopTimeout(t *testing.T) {
+	start := time.Date(2021, 1, 2, 15, 4, 0, 0, time.UTC)
+	s, c, finished := newTestScheduler(start)
+	s.Register("blocked", Every(time.Minute), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	s.Start(context.Background())
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	// wait for the run to start before stopping
+	for {
+		s.jobs["blocked"].lock.Lock()
+		running := s.jobs["blocked"].running
+		s.jobs["blocked"].lock.Unlock()
+		if running > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := s.Stop(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Stop = %+v ,expected %+v", err, context.DeadlineExceeded)
+	}
+	e := waitEvent(t, finished)
+	if e.Err != context.Canceled {
+		t.Errorf("Err = %+v ,expected %+v", e.Err, context.Canceled)
+	}
+}
@@ -0,0 +1,204 @@
+package timkit
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultSlotHorizon is how far FindSlots searches when the query has no horizon
+const DefaultSlotHorizon = 2 * daysPerWeek * hoursPerDay * time.Hour
+
+var ErrInvalidSlotQuery = errors.New("timkit: slot query needs a start , a positive duration and a positive granularity")
+
+// HolidayCalendar tell whether a date is a holiday
+type HolidayCalendar interface {
+	IsHoliday(date *TimeKit) bool
+}
+
+// HolidayFunc adapt an ordinary function to a HolidayCalendar
+type HolidayFunc func(date *TimeKit) bool
+
+// IsHoliday call f(date)
+func (f HolidayFunc) IsHoliday(date *TimeKit) bool {
+	return f(date)
+}
+
+// Holidays is a set of dates in DateFormat
+type Holidays map[string]bool
+
+// NewHolidays return the set of the given dates , e.g. "2021-01-01"
+func NewHolidays(dates ...string) Holidays {
+	h := make(Holidays, len(dates))
+	for _, d := range dates {
+		h[d] = true
+	}
+	return h
+}
+
+// IsHoliday whether the date of the given time is in the set
+func (h Holidays) IsHoliday(date *TimeKit) bool {
+	return h[date.DateString()]
+}
+
+// WorkingHours is a daily range of wall clock time counted from the local midnight .
+// An End before Start ends on the next day , the zero value is the whole day
+type WorkingHours struct {
+	Start time.Duration
+	End   time.Duration
+	// Days are the working days , empty for the weekdays
+	Days []time.Weekday
+}
+
+// atClock return the wall clock time d after the midnight of a date given in UTC , in the location l .
+// A wall clock time skipped by a DST change is moved to the end of the gap
+func atClock(date time.Time, d time.Duration, l *time.Location) time.Time {
+	w := date.Add(d)
+	t, _ := resolveWallTime(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), l, WallTimeShiftForward)
+	return t
+}
+
+func (w WorkingHours) isWorkingDay(day time.Time) bool {
+	if len(w.Days) == 0 {
+		return NewTimeKit(day).IsWeekday()
+	}
+	for _, d := range w.Days {
+		if d == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// spans return the working time in [from, to) for the location l
+func (w WorkingHours) spans(l *time.Location, from, to time.Time, holidays HolidayCalendar) []span {
+	var out []span
+	f := from.In(l)
+	// step through the dates in UTC , a local midnight may be skipped by a DST change .
+	// Start the day before for the hours ending after midnight
+	for date := time.Date(f.Year(), f.Month(), f.Day()-1, 0, 0, 0, 0, time.UTC); ; date = date.AddDate(0, 0, 1) {
+		day := atClock(date, 0, l)
+		if !day.Before(to) {
+			break
+		}
+		if !w.isWorkingDay(day) || (holidays != nil && holidays.IsHoliday(NewTimeKit(day))) {
+			continue
+		}
+		s := atClock(date, w.Start, l)
+		e := atClock(date, w.End, l)
+		if !e.After(s) {
+			e = atClock(date.AddDate(0, 0, 1), w.End, l)
+		}
+		if s.Before(from) {
+			s = from
+		}
+		if e.After(to) {
+			e = to
+		}
+		if s.Before(e) {
+			out = append(out, span{s, e})
+		}
+	}
+	return out
+}
+
+// Participant is an attendee whose availability is checked by FindSlots
+type Participant struct {
+	// Location is the zone of the working hours and of the holidays , empty for Local
+	Location     string
+	WorkingHours WorkingHours
+	Busy         []Period
+	// Calendar is an optional calendar whose occurrences are busy time too
+	Calendar *Calendar
+	Holidays HolidayCalendar
+}
+
+// free return the time the participant can attend in [from, to) .
+// The busy time is widened by the buffers so the slots keep them free
+func (p Participant) free(from, to time.Time, before, after time.Duration) ([]span, error) {
+	l := time.Local
+	if p.Location != "" {
+		var err error
+		if l, err = loadLocation(p.Location); err != nil {
+			return nil, err
+		}
+	}
+	busy := make([]span, 0, len(p.Busy))
+	for _, b := range p.Busy {
+		busy = append(busy, span{b.Start.Add(-after), b.End.Add(before)})
+	}
+	if p.Calendar != nil {
+		c := p.Calendar
+		c.lock.RLock()
+		for _, o := range c.between(from.Add(-before), to.Add(after)) {
+			busy = append(busy, span{o.Start.Add(-after), o.End.Add(before)})
+		}
+		c.lock.RUnlock()
+	}
+	working := mergeSpans(p.WorkingHours.spans(l, from, to, p.Holidays))
+	return subtractSpans(working, mergeSpans(busy)), nil
+}
+
+// SlotQuery describe the slots searched by FindSlots
+type SlotQuery struct {
+	// From is the earliest start of a slot , the slots are returned in its location
+	From *TimeKit
+	// Horizon is how far after From the slots may end , 0 for DefaultSlotHorizon
+	Horizon  time.Duration
+	Duration time.Duration
+	// Granularity is the step between two slot starts on the local clock of From , 0 for 15 minutes
+	Granularity time.Duration
+	// BufferBefore and BufferAfter is the free time required around a slot
+	BufferBefore time.Duration
+	BufferAfter  time.Duration
+	// Limit is the number of slots returned , 0 for 1
+	Limit int
+}
+
+// alignUp return the first time not before t on the local grid of step g
+func alignUp(t time.Time, g time.Duration, l *time.Location) time.Time {
+	w := wallClock(t.In(l))
+	r := w.Sub(time.Date(w.Year(), w.Month(), w.Day(), 0, 0, 0, 0, time.UTC)) % g
+	if r == 0 {
+		return t
+	}
+	return t.Add(g - r)
+}
+
+// FindSlots return the first free slots working for every participant as [start, end) periods
+func FindSlots(q SlotQuery, participants ...Participant) ([]Period, error) {
+	if q.From == nil || q.Duration <= 0 || q.Granularity < 0 || q.Horizon < 0 || q.Limit < 0 {
+		return nil, ErrInvalidSlotQuery
+	}
+	if q.Horizon == 0 {
+		q.Horizon = DefaultSlotHorizon
+	}
+	if q.Granularity == 0 {
+		q.Granularity = 15 * time.Minute
+	}
+	if q.Limit == 0 {
+		q.Limit = 1
+	}
+
+	from := q.From.Time
+	to := from.Add(q.Horizon)
+	free := []span{{from, to}}
+	for _, p := range participants {
+		f, err := p.free(from, to, q.BufferBefore, q.BufferAfter)
+		if err != nil {
+			return nil, err
+		}
+		free = intersectSpans(free, f)
+	}
+
+	l := q.From.Location()
+	var slots []Period
+	for _, f := range free {
+		for s := alignUp(f.start, q.Granularity, l); !s.Add(q.Duration).After(f.end); s = alignUp(s.Add(q.Granularity), q.Granularity, l) {
+			slots = append(slots, span{s, s.Add(q.Duration)}.period(l))
+			if len(slots) == q.Limit {
+				return slots, nil
+			}
+		}
+	}
+	return slots, nil
+}
@@ -0,0 +1,98 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func periodStrings(periods []Period) []string {
+	out := make([]string, 0, len(periods))
+	for _, p := range periods {
+		out = append(out, p.String())
+	}
+	return out
+}
+
+func TestFindSlots(t *testing.T) {
+	from, err := Parse(DefaultFormat, "2021-01-04 08:00:00", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	busy, _ := Parse(DefaultFormat, "2021-01-04 09:00:00", "UTC")
+	busyEnd, _ := Parse(DefaultFormat, "2021-01-04 10:00:00", "UTC")
+
+	london := Participant{
+		Location:     "Europe/London",
+		WorkingHours: WorkingHours{Start: 9 * time.Hour, End: 17 * time.Hour},
+		Busy:         []Period{NewPeriod(busy, busyEnd)},
+	}
+	shanghai := Participant{
+		Location:     "Asia/Shanghai",
+		WorkingHours: WorkingHours{Start: 9 * time.Hour, End: 18*time.Hour + 45*time.Minute},
+		Holidays:     NewHolidays("2021-01-05"),
+	}
+
+	slots, err := FindSlots(SlotQuery{
+		From:         from,
+		Duration:     30 * time.Minute,
+		BufferBefore: 10 * time.Minute,
+		Limit:        3,
+	}, london, shanghai)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10:00-10:45 UTC is the overlap once the busy hour and its buffer are removed ,
+	// 2021-01-05 is a holiday in Shanghai
+	expected := []string{
+		"2021-01-04 10:15:00 - 2021-01-04 10:45:00",
+		"2021-01-06 09:00:00 - 2021-01-06 09:30:00",
+		"2021-01-06 09:15:00 - 2021-01-06 09:45:00",
+	}
+	if got := periodStrings(slots); !equalStrings(got, expected) {
+		t.Errorf("FindSlots = %+v ,expected %+v", got, expected)
+	}
+}
+
+func TestFindSlots_Invalid(t *testing.T) {
+	if _, err := FindSlots(SlotQuery{From: Now()}); err != ErrInvalidSlotQuery {
+		t.Errorf("FindSlots = %+v ,expected %+v", err, ErrInvalidSlotQuery)
+	}
+}
+
+func TestFindSlots_SkippedMidnight(t *testing.T) {
+	everyDay := []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+
+	// the clock moved from 00:00 to 01:00 in Havana on 2021-03-14
+	from, err := Parse(DefaultFormat, "2021-03-13 22:00:00", "America/Havana")
+	if err != nil {
+		t.Skip(err)
+	}
+	slots, err := FindSlots(SlotQuery{From: from, Duration: time.Hour, Granularity: time.Hour, Limit: 3},
+		Participant{Location: "America/Havana", WorkingHours: WorkingHours{Days: everyDay}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"2021-03-13 22:00:00 - 2021-03-13 23:00:00",
+		"2021-03-13 23:00:00 - 2021-03-14 01:00:00",
+		"2021-03-14 01:00:00 - 2021-03-14 02:00:00",
+	}
+	if got := periodStrings(slots); !equalStrings(got, expected) {
+		t.Errorf("FindSlots = %+v ,expected %+v", got, expected)
+	}
+
+	// and in Santiago on 2021-09-05
+	from, err = Parse(DefaultFormat, "2021-09-04 18:00:00", "America/Santiago")
+	if err != nil {
+		t.Skip(err)
+	}
+	slots, err = FindSlots(SlotQuery{From: from, Duration: time.Hour, Limit: 1},
+		Participant{Location: "America/Santiago", WorkingHours: WorkingHours{Start: 9 * time.Hour, End: 17 * time.Hour, Days: everyDay}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{"2021-09-05 09:00:00 - 2021-09-05 10:00:00"}
+	if got := periodStrings(slots); !equalStrings(got, expected) {
+		t.Errorf("FindSlots = %+v ,expected %+v", got, expected)
+	}
+}
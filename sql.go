@@ -0,0 +1,119 @@
+package timkit
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// StorageMode is how a TimeKit is stored in a database column
+type StorageMode int
+
+const (
+	// StorageDefault use DefaultStorageMode
+	StorageDefault StorageMode = iota
+	// StorageTime store a time.Time handled by the driver , e.g. DATETIME or TIMESTAMP
+	StorageTime
+	// StorageString store the time with the format of the instance
+	StorageString
+	// StorageUnix store the seconds since Jan 1 1970
+	StorageUnix
+	// StorageUnixMilli store the milliseconds since Jan 1 1970
+	StorageUnixMilli
+)
+
+// DefaultStorageMode is the storage mode of the instances without one , set it during initialization
+var DefaultStorageMode = StorageTime
+
+// ScanLocation is the session location applied to the scanned times .
+// nil keeps the location of the driver , and parses the strings without a zone in UTC
+var ScanLocation *time.Location
+
+var ErrScanNull = errors.New("timkit: cannot scan NULL into a TimeKit , use NullTimeKit")
+
+// OptionSetStorageMode set how the time is stored in a database
+func OptionSetStorageMode(m StorageMode) Option {
+	return func(t *TimeKit) {
+		t.storageMode = m
+	}
+}
+
+// SetStorageMode set how the time is stored in a database
+func (tk *TimeKit) SetStorageMode(m StorageMode) {
+	tk.lock.Lock()
+	defer tk.lock.Unlock()
+	tk.storageMode = m
+}
+
+// StorageMode return how the time is stored in a database
+func (tk *TimeKit) StorageMode() StorageMode {
+	if tk.storageMode == StorageDefault {
+		return DefaultStorageMode
+	}
+	return tk.storageMode
+}
+
+// Value implements the driver.Valuer interface using the storage mode
+func (tk *TimeKit) Value() (driver.Value, error) {
+	if tk == nil {
+		return nil, nil
+	}
+	switch tk.StorageMode() {
+	case StorageString:
+		return tk.String(), nil
+	case StorageUnix:
+		return tk.Unix(), nil
+	case StorageUnixMilli:
+		return unixMilli(tk.Time), nil
+	}
+	return tk.Time, nil
+}
+
+// Scan implements the sql.Scanner interface .
+// It accepts time.Time , the strings and bytes of the unmarshalers and unix timestamps ,
+// integers are read as milliseconds with StorageUnixMilli and as seconds otherwise
+func (tk *TimeKit) Scan(src interface{}) error {
+	tk.initSettings()
+	milli := tk.StorageMode() == StorageUnixMilli
+	l := ScanLocation
+	if l == nil {
+		l = time.UTC
+	}
+
+	var t time.Time
+	switch v := src.(type) {
+	case nil:
+		return ErrScanNull
+	case time.Time:
+		t = v
+		if ScanLocation != nil {
+			t = t.In(ScanLocation)
+		}
+	case int64:
+		t, _ = parseUnix(strconv.FormatInt(v, 10), l, milli)
+	case float64:
+		var ok bool
+		if t, ok = parseUnix(strconv.FormatFloat(v, 'f', -1, 64), l, milli); !ok {
+			return fmt.Errorf("timkit: cannot scan %v into a TimeKit", v)
+		}
+	case []byte:
+		return tk.scanString(string(v), l, milli)
+	case string:
+		return tk.scanString(v, l, milli)
+	default:
+		return fmt.Errorf("timkit: cannot scan %T into a TimeKit", src)
+	}
+	tk.SetTime(t)
+	return nil
+}
+
+func (tk *TimeKit) scanString(s string, l *time.Location, milli bool) error {
+	t, err := parseTime(s, l, milli, append([]string{tk.format, "2006-01-02 15:04:05.999999999"}, ParseLayouts...)...)
+	if err != nil {
+		return err
+	}
+	tk.SetTime(t)
+	return nil
+}
@@ -0,0 +1,72 @@
+package timkit
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimeKit_Value(t *testing.T) {
+	tm := time.Date(2021, 1, 2, 15, 4, 5, 6000000, time.UTC)
+	cases := []struct {
+		mode     StorageMode
+		expected interface{}
+	}{
+		{StorageDefault, tm},
+		{StorageString, "2021-01-02 15:04:05"},
+		{StorageUnix, int64(1609599845)},
+		{StorageUnixMilli, int64(1609599845006)},
+	}
+	for _, c := range cases {
+		v, err := NewOptions(OptionSetTime(tm), OptionSetStorageMode(c.mode)).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != c.expected {
+			t.Errorf("Value = %+v ,expected %+v", v, c.expected)
+		}
+	}
+
+	var null *TimeKit
+	if v, err := null.Value(); v != nil || err != nil {
+		t.Errorf("Value = %+v %+v ,expected nil", v, err)
+	}
+}
+
+func TestTimeKit_Scan(t *testing.T) {
+	l, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+	ScanLocation = l
+	defer func() {
+		ScanLocation = nil
+	}()
+
+	expected := time.Date(2021, 1, 2, 15, 4, 5, 0, l)
+	sources := []interface{}{
+		expected.UTC(),
+		"2021-01-02 15:04:05",
+		[]byte("2021-01-02 15:04:05.000000"),
+		expected.Unix(),
+	}
+	for _, src := range sources {
+		var tk TimeKit
+		if err := tk.Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		if !tk.Equal(expected) || tk.Location() != l {
+			t.Errorf("Scan(%v) = %+v ,expected %+v", src, tk.Time, expected)
+		}
+	}
+
+	var tk TimeKit
+	if err := tk.Scan(nil); err != ErrScanNull {
+		t.Errorf("Scan(nil) = %+v ,expected %+v", err, ErrScanNull)
+	}
+	for _, src := range []float64{math.NaN(), math.Inf(1), 1e300} {
+		if err := tk.Scan(src); err == nil {
+			t.Errorf("Scan(%v) = %+v ,expected an error", src, tk.Time)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package timkit
+
+import (
+	"time"
+)
+
+// TemplateOption configure the functions returned by FuncMap
+type TemplateOption func(f *templateFuncs)
+
+type templateFuncs struct {
+	locale   string
+	holidays HolidayCalendar
+}
+
+// TemplateOptionSetLocale set the locale of `format` and `diffForHumans` , DefaultLocale by default
+func TemplateOptionSetLocale(locale string) TemplateOption {
+	return func(f *templateFuncs) {
+		f.locale = locale
+	}
+}
+
+// TemplateOptionSetHolidays set the holidays skipped by `isBusinessDay`
+func TemplateOptionSetHolidays(h HolidayCalendar) TemplateOption {
+	return func(f *templateFuncs) {
+		f.holidays = h
+	}
+}
+
+// FuncMap return the functions for text/template and html/template , e.g.
+//
+//	{{ .Created | inZone "Asia/Shanghai" | format "2006-01-02 15:04" }}
+//	{{ .Due | startOf "week" | addDays 7 | formatLocale "Monday" "zh" }}
+//
+// The time is the last argument so the functions can be chained in pipelines .
+// It may be a *TimeKit , TimeKit , NullTimeKit , time.Time or a pointer to them ,
+// it is never modified . A nil , null or zero time gives an empty string , false or nil
+func FuncMap(opt ...TemplateOption) map[string]interface{} {
+	f := &templateFuncs{locale: DefaultLocale}
+	for _, o := range opt {
+		o(f)
+	}
+
+	return map[string]interface{}{
+		"now":                 Now,
+		"format":              f.format,
+		"formatLocale":        formatLocale,
+		"dateString":          dateString,
+		"dateTimeString":      dateTimeString,
+		"timeString":          timeString,
+		"diffForHumans":       f.diffForHumans,
+		"diffForHumansLocale": diffForHumansLocale,
+		"startOf":             startOf,
+		"endOf":               endOf,
+		"addDuration":         addDuration,
+		"addMinutes":          addFunc((*TimeKit).AddMinutes),
+		"addHours":            addFunc((*TimeKit).AddHours),
+		"addDays":             addFunc((*TimeKit).AddDays),
+		"addWeeks":            addFunc((*TimeKit).AddWeeks),
+		"addWeekdays":         addFunc((*TimeKit).AddWeekdays),
+		"addMonths":           addFunc((*TimeKit).AddMonthsNoOverflow),
+		"addYears":            addFunc((*TimeKit).AddYears),
+		"inZone":              inZone,
+		"isWeekday":           isWeekday,
+		"isWeekend":           isWeekend,
+		"isBusinessDay":       f.isBusinessDay,
+		"unix":                unix,
+	}
+}
+
+// templateTime return a copy of the time of a template value , nil for a nil , null or zero time
+func templateTime(v interface{}) *TimeKit {
+	var tk *TimeKit
+	switch t := v.(type) {
+	case *TimeKit:
+		if t != nil {
+			tk = t.Copy()
+		}
+	case TimeKit:
+		tk = (&t).Copy()
+	case *NullTimeKit:
+		if t != nil && t.Valid && t.TimeKit != nil {
+			tk = t.TimeKit.Copy()
+		}
+	case NullTimeKit:
+		if t.Valid && t.TimeKit != nil {
+			tk = t.TimeKit.Copy()
+		}
+	case *time.Time:
+		if t != nil {
+			tk = NewTimeKit(*t)
+		}
+	case time.Time:
+		tk = NewTimeKit(t)
+	}
+	if tk == nil || tk.IsZero() {
+		return nil
+	}
+	return tk
+}
+
+func (f *templateFuncs) format(layout string, v interface{}) (string, error) {
+	return formatLocale(layout, f.locale, v)
+}
+
+func formatLocale(layout, locale string, v interface{}) (string, error) {
+	tk := templateTime(v)
+	if tk == nil {
+		return "", nil
+	}
+	return tk.FormatLocale(layout, locale)
+}
+
+func dateString(v interface{}) string {
+	if tk := templateTime(v); tk != nil {
+		return tk.DateString()
+	}
+	return ""
+}
+
+func dateTimeString(v interface{}) string {
+	if tk := templateTime(v); tk != nil {
+		return tk.DateTimeString()
+	}
+	return ""
+}
+
+func timeString(v interface{}) string {
+	if tk := templateTime(v); tk != nil {
+		return tk.TimeString()
+	}
+	return ""
+}
+
+func (f *templateFuncs) diffForHumans(v interface{}) (string, error) {
+	return diffForHumansLocale(f.locale, v)
+}
+
+func diffForHumansLocale(locale string, v interface{}) (string, error) {
+	tk := templateTime(v)
+	if tk == nil {
+		return "", nil
+	}
+	return tk.DiffForHumansLocale(nil, locale)
+}
+
+func startOf(unit string, v interface{}) (*TimeKit, error) {
+	u, err := ParseUnit(unit)
+	if err != nil {
+		return nil, err
+	}
+	if tk := templateTime(v); tk != nil {
+		return tk.StartOf(u), nil
+	}
+	return nil, nil
+}
+
+func endOf(unit string, v interface{}) (*TimeKit, error) {
+	u, err := ParseUnit(unit)
+	if err != nil {
+		return nil, err
+	}
+	if tk := templateTime(v); tk != nil {
+		return tk.EndOf(u), nil
+	}
+	return nil, nil
+}
+
+// addDuration add a duration such as "1h30m" , see time.ParseDuration
+func addDuration(duration string, v interface{}) (*TimeKit, error) {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return nil, err
+	}
+	if tk := templateTime(v); tk != nil {
+		tk.SetTime(tk.Add(d))
+		return tk, nil
+	}
+	return nil, nil
+}
+
+// addFunc adapt an AddX method to a template function
+func addFunc(add func(tk *TimeKit, n int) *TimeKit) func(n int, v interface{}) *TimeKit {
+	return func(n int, v interface{}) *TimeKit {
+		if tk := templateTime(v); tk != nil {
+			return add(tk, n)
+		}
+		return nil
+	}
+}
+
+func inZone(location string, v interface{}) (*TimeKit, error) {
+	l, err := loadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	if tk := templateTime(v); tk != nil {
+		tk.SetTime(tk.In(l))
+		return tk, nil
+	}
+	return nil, nil
+}
+
+func isWeekday(v interface{}) bool {
+	tk := templateTime(v)
+	return tk != nil && tk.IsWeekday()
+}
+
+func isWeekend(v interface{}) bool {
+	tk := templateTime(v)
+	return tk != nil && tk.IsWeekend()
+}
+
+func (f *templateFuncs) isBusinessDay(v interface{}) bool {
+	tk := templateTime(v)
+	return tk != nil && tk.IsWeekday() && (f.holidays == nil || !f.holidays.IsHoliday(tk))
+}
+
+func unix(v interface{}) int64 {
+	if tk := templateTime(v); tk != nil {
+		return tk.Unix()
+	}
+	return 0
+}
@@ -0,0 +1,73 @@
+package timkit
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestFuncMap(t *testing.T) {
+	tk := NewTimeKit(time.Date(2021, 1, 1, 20, 4, 5, 0, time.UTC))
+	data := map[string]interface{}{
+		"TimeKit": tk,
+		"Time":    tk.Time,
+		"Nil":     (*TimeKit)(nil),
+		"Null":    NullTimeKit{},
+		"Zero":    time.Time{},
+	}
+	funcs := FuncMap(TemplateOptionSetHolidays(NewHolidays("2021-01-01")))
+	tests := []struct {
+		text, expected string
+	}{
+		{`{{ .TimeKit | inZone "Asia/Shanghai" | format "2006-01-02 15:04 Mon" }}`, "2021-01-02 04:04 Sat"},
+		{`{{ .Time | startOf "week" | addDays 2 | dateString }}`, "2020-12-30"},
+		{`{{ .TimeKit | endOf "day" | dateTimeString }}`, "2021-01-01 23:59:59"},
+		{`{{ .TimeKit | endOf "month" | addMonths 1 | dateString }}`, "2021-02-28"},
+		{`{{ .TimeKit | addMonths 1 | addDuration "1h30m" | formatLocale "January 15:04" "zh" }}`, "二月 21:34"},
+		{`{{ isWeekday .TimeKit }} {{ isBusinessDay .TimeKit }} {{ .TimeKit | addDays 4 | isBusinessDay }}`, "true false true"},
+		{`[{{ format "2006" .Nil }}{{ .Null | dateString }}{{ .Zero | diffForHumans }}{{ .Missing | timeString }}]`, "[]"},
+		{`{{ isWeekend .Nil }} {{ unix .Null }}`, "false 0"},
+		{`{{ .TimeKit }}`, "2021-01-01 20:04:05"},
+	}
+	for _, test := range tests {
+		var b bytes.Buffer
+		tpl := template.Must(template.New("").Funcs(funcs).Parse(test.text))
+		if err := tpl.Execute(&b, data); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != test.expected {
+			t.Errorf("%s = %+v ,expected %+v", test.text, b.String(), test.expected)
+		}
+	}
+	if !tk.Equal(time.Date(2021, 1, 1, 20, 4, 5, 0, time.UTC)) || tk.Location() != time.UTC {
+		t.Errorf("TimeKit = %+v ,expected it is not modified", tk)
+	}
+
+	var b bytes.Buffer
+	tpl := template.Must(template.New("").Funcs(funcs).Parse(`{{ .TimeKit | startOf "fortnight" }}`))
+	if err := tpl.Execute(&b, data); err == nil {
+		t.Errorf("startOf an unknown unit ,expected an error")
+	}
+
+	html := htmltemplate.Must(htmltemplate.New("").Funcs(FuncMap(TemplateOptionSetLocale("zh"))).Parse(`<p>{{ .TimeKit | format "Monday" }}</p>`))
+	b.Reset()
+	if err := html.Execute(&b, data); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "<p>星期五</p>" {
+		t.Errorf("html = %+v ,expected %+v", b.String(), "<p>星期五</p>")
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	for name, expected := range map[string]Unit{"day": UnitDay, "Weeks": UnitWeek, " year ": UnitYear} {
+		if u, err := ParseUnit(name); err != nil || u != expected {
+			t.Errorf("ParseUnit(%q) = %+v ,expected %+v", name, u, expected)
+		}
+	}
+	if _, err := ParseUnit("fortnight"); err != ErrInvalidUnit {
+		t.Errorf("ParseUnit error = %+v ,expected %+v", err, ErrInvalidUnit)
+	}
+}
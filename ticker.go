@@ -0,0 +1,89 @@
+package timkit
+
+import (
+	"sync"
+	"time"
+)
+
+// AlignedTicker deliver the start of every unit on its channel .
+// Unlike time.Ticker it fires on the calendar boundaries of its location ,
+// e.g. at the start of every minute or at the start of every week
+type AlignedTicker struct {
+	C <-chan *TimeKit
+
+	c        chan *TimeKit
+	unit     Unit
+	location *time.Location
+	opt      []Option
+	clock    Clock
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// NewAlignedTicker return a new AlignedTicker firing at the start of every unit in the given location .
+// The options are applied to the delivered instances , e.g. OptionSetWeekStartAt for UnitWeek
+func NewAlignedTicker(unit Unit, location string, opt ...Option) (*AlignedTicker, error) {
+	if !unit.valid() {
+		return nil, ErrInvalidUnit
+	}
+	l, err := loadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	c := make(chan *TimeKit, 1)
+	at := &AlignedTicker{
+		C:        c,
+		c:        c,
+		unit:     unit,
+		location: l,
+		opt:      opt,
+		clock:    GetClock(),
+		stop:     make(chan struct{}),
+	}
+	go at.run()
+	return at, nil
+}
+
+// Stop turn off the ticker , no more boundaries are delivered after Stop returns
+func (at *AlignedTicker) Stop() {
+	at.once.Do(func() {
+		close(at.stop)
+	})
+}
+
+// next return the first boundary strictly after t
+func (at *AlignedTicker) next(t time.Time) *TimeKit {
+	opt := append([]Option{OptionSetTime(t.In(at.location))}, at.opt...)
+	return NewOptions(opt...).nextBoundary(at.unit)
+}
+
+func (at *AlignedTicker) run() {
+	next := at.next(at.clock.Now())
+	for {
+		timer := at.clock.NewTimer(next.Sub(at.clock.Now()))
+		select {
+		case <-at.stop:
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+
+		select {
+		case <-at.stop:
+			return
+		default:
+		}
+		// drop the tick when the receiver is behind , as time.Ticker does
+		select {
+		case at.c <- next:
+		default:
+		}
+
+		// boundaries missed while the process was suspended are skipped
+		after := at.clock.Now()
+		if after.Before(next.Time) {
+			after = next.Time
+		}
+		next = at.next(after)
+	}
+}
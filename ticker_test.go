@@ -0,0 +1,132 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func receiveTick(t *testing.T, at *AlignedTicker) *TimeKit {
+	select {
+	case b := <-at.C:
+		return b
+	case <-time.After(time.Second):
+		t.Fatalf("no boundary received")
+	}
+	return nil
+}
+
+func TestAlignedTicker_Minute(t *testing.T) {
+	c := NewFakeClock(time.Date(2021, 1, 2, 15, 4, 30, 0, time.UTC))
+	SetClock(c)
+	defer SetClock(nil)
+
+	at, err := NewAlignedTicker(UnitMinute, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer at.Stop()
+
+	for _, expected := range []string{"2021-01-02 15:05:00", "2021-01-02 15:06:00"} {
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+		if b := receiveTick(t, at); b.String() != expected {
+			t.Errorf("boundary = %+v ,expected %+v", b, expected)
+		}
+	}
+}
+
+func TestAlignedTicker_Week(t *testing.T) {
+	c := NewFakeClock(time.Date(2021, 1, 6, 15, 4, 5, 0, time.UTC))
+	SetClock(c)
+	defer SetClock(nil)
+
+	at, err := NewAlignedTicker(UnitWeek, "UTC", OptionSetWeekStartAt(time.Sunday))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer at.Stop()
+
+	c.BlockUntil(1)
+	c.Advance(7 * 24 * time.Hour)
+	if b := receiveTick(t, at); b.String() != "2021-01-10 00:00:00" {
+		t.Errorf("boundary = %+v ,expected %+v", b, "2021-01-10 00:00:00")
+	}
+}
+
+func TestAlignedTicker_DST(t *testing.T) {
+	l, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	c := NewFakeClock(time.Date(2021, 3, 13, 12, 0, 0, 0, l))
+	SetClock(c)
+	defer SetClock(nil)
+
+	at, err := NewAlignedTicker(UnitDay, "America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer at.Stop()
+
+	c.BlockUntil(1)
+	c.Advance(12 * time.Hour)
+	first := receiveTick(t, at)
+	c.BlockUntil(1)
+	c.Advance(23 * time.Hour)
+	second := receiveTick(t, at)
+
+	if second.String() != "2021-03-15 00:00:00" {
+		t.Errorf("boundary = %+v ,expected %+v", second, "2021-03-15 00:00:00")
+	}
+	if d := second.Sub(first.Time); d != 23*time.Hour {
+		t.Errorf("day length = %+v ,expected %+v", d, 23*time.Hour)
+	}
+}
+
+func TestAlignedTicker_SkippedMidnight(t *testing.T) {
+	// the clock moved from 00:00 to 01:00 in Havana on 2021-03-14
+	l, err := time.LoadLocation("America/Havana")
+	if err != nil {
+		t.Skip(err)
+	}
+	c := NewFakeClock(time.Date(2021, 3, 13, 23, 30, 0, 0, l))
+	SetClock(c)
+	defer SetClock(nil)
+
+	at, err := NewAlignedTicker(UnitDay, "America/Havana")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer at.Stop()
+
+	c.BlockUntil(1)
+	c.Advance(30 * time.Minute)
+	if b := receiveTick(t, at); b.Time.String() != "2021-03-14 01:00:00 -0400 CDT" {
+		t.Errorf("boundary = %+v ,expected %+v", b.Time, "2021-03-14 01:00:00 -0400 CDT")
+	}
+	c.BlockUntil(1)
+	c.Advance(23 * time.Hour)
+	if b := receiveTick(t, at); b.String() != "2021-03-15 00:00:00" {
+		t.Errorf("boundary = %+v ,expected %+v", b, "2021-03-15 00:00:00")
+	}
+
+	for _, u := range []Unit{UnitWeek, UnitMonth} {
+		tk := NewTimeKit(time.Date(2021, 3, 13, 23, 30, 0, 0, l))
+		if b := tk.nextBoundary(u); !b.After(tk.Time) {
+			t.Errorf("nextBoundary(%s) = %+v ,expected after %+v", u, b.Time, tk.Time)
+		}
+	}
+}
+
+func TestTimeKit_StartOfHour(t *testing.T) {
+	l, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skip(err)
+	}
+	tk := NewTimeKit(time.Date(2021, 1, 2, 15, 4, 5, 6, l))
+	expected := NewTimeKit(time.Date(2021, 1, 2, 15, 0, 0, 0, l))
+	tk.StartOf(UnitHour)
+	if !tk.Equal(expected.Time) {
+		t.Errorf("StartOfHour = %+v ,expected %+v", tk, expected)
+	}
+}
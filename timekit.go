@@ -12,7 +12,8 @@
 //   See the License for the specific language governing permissions and
 //   limitations under the License.
 
-//  package `timkit` is a time toolkit for Golang reference PHP's library `Carbon`
+//  package `timkit` is a time toolkit for Golang reference PHP's library `Carbon` .
+//  It needs Go 1.17 , which read the POSIX TZ rules and the daylight saving time flags of the time zone data
 package timkit
 
 import (
@@ -51,7 +52,11 @@ type TimeKit struct {
 	weekendDays []time.Weekday
 	weekStartAt time.Weekday
 	weekEndAt   time.Weekday
-	lock        sync.Mutex
+	wireFormat  WireFormat
+	storageMode StorageMode
+	// wallTimePolicy resolve the skipped and ambiguous wall times
+	wallTimePolicy WallTimePolicy
+	lock           sync.Mutex
 }
 
 // NewTimeKit return a pointer to a new NewTimeKit instance
@@ -63,7 +68,7 @@ type Option func(t *TimeKit)
 
 func NewOptions(opt ...Option) *TimeKit {
 	t := TimeKit{
-		Time:        time.Now(),
+		Time:        now(),
 		format:      DefaultFormat,
 		weekendDays: []time.Weekday{time.Saturday, time.Sunday},
 		weekStartAt: time.Monday,
@@ -78,12 +83,12 @@ func NewOptions(opt ...Option) *TimeKit {
 
 // Now return a new TimeKit instance for current time in local
 func Now() *TimeKit {
-	return NewTimeKit(time.Now())
+	return NewTimeKit(now())
 }
 
 // NowWithLocation return a new TimeKit instance for current time in given location
 func NowWithLocation(loc string) (*TimeKit, error) {
-	l, err := time.LoadLocation(loc)
+	l, err := loadLocation(loc)
 	if err != nil {
 		return nil, err
 	}
@@ -94,8 +99,9 @@ func NowWithLocation(loc string) (*TimeKit, error) {
 // layout reference `DefaultFormat` or time.ANSIC
 // value reference `2020-12-23 11:13:11`
 // location default `UTC` .
-func Parse(layout, value, location string) (*TimeKit, error) {
-	l, err := time.LoadLocation(location)
+// A value without zone is resolved by the policy set by OptionSetWallTimePolicy
+func Parse(layout, value, location string, opt ...Option) (*TimeKit, error) {
+	l, err := loadLocation(location)
 	if err != nil {
 		return nil, err
 	}
@@ -103,13 +109,18 @@ func Parse(layout, value, location string) (*TimeKit, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewTimeKit(t), nil
+	tk := NewOptions(opt...)
+	if t, err = parseWallTime(layout, value, t, tk.wallTimePolicy); err != nil {
+		return nil, err
+	}
+	tk.SetTime(t)
+	return tk, nil
 }
 
 // CreateFromTimestamp return a new TimeKit instance from a timestamp
 // If the location is invalid , it return an error
 func CreateFromTimestamp(timestamp int64, location string) (*TimeKit, error) {
-	l, err := time.LoadLocation(location)
+	l, err := loadLocation(location)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +148,7 @@ func OptionSetWeekStartAt(s time.Weekday) Option {
 // OptionSetWeekEndAt set end of week
 func OptionSetWeekEndAt(e time.Weekday) Option {
 	return func(t *TimeKit) {
-		t.weekStartAt = e
+		t.weekEndAt = e
 	}
 }
 
@@ -293,7 +304,7 @@ func (tk *TimeKit) SubMonth() *TimeKit {
 func (tk *TimeKit) AddMonthsNoOverflow(m int) *TimeKit {
 	newDate := tk.AddDate(0, m, 0)
 	if tk.Day() != newDate.Day() {
-		newDate = tk.LastDayOfPreMonth()
+		newDate = newDate.AddDate(0, 0, -newDate.Day())
 	}
 	tk.SetTime(newDate)
 	return tk
@@ -430,10 +441,11 @@ func (tk *TimeKit) SubYear() *TimeKit {
 	return tk.SubYears(1)
 }
 
-// DiffInSeconds return the difference in seconds
+// DiffInSeconds return the difference in seconds , positive when t is after tk as for the other
+// Diff methods except DiffInMonths
 func (tk *TimeKit) DiffInSeconds(t *TimeKit, abs bool) int64 {
 	if t == nil {
-		t = createFromTimestamp(time.Now().Unix(), tk.Location())
+		t = createFromTimestamp(now().Unix(), tk.Location())
 	}
 	diff := t.Timestamp() - tk.Timestamp()
 	return absoluteValue(abs, diff)
@@ -449,29 +461,83 @@ func (tk *TimeKit) DiffInHours(t *TimeKit, abs bool) int64 {
 	return tk.DiffInMinutes(t, abs) / minutesPerHour
 }
 
-// DiffInDays return the difference in days
+// DiffInDays return the difference in whole days , a day is complete when the wall clock of t
+// in the location of tk reach the wall clock of tk , so a day across a DST change count for one .
+// It is positive when t is after tk
 func (tk *TimeKit) DiffInDays(t *TimeKit, abs bool) int64 {
+	if t == nil {
+		t = createFromTimestamp(now().Unix(), tk.Location())
+	}
+	a, b := wallClock(tk.Time), wallClock(t.In(tk.Location()))
+	days := civilDays(a, b)
+	clockA := a.Sub(a.Truncate(hoursPerDay * time.Hour))
+	clockB := b.Sub(b.Truncate(hoursPerDay * time.Hour))
+	if days > 0 && clockB < clockA {
+		days--
+	} else if days < 0 && clockB > clockA {
+		days++
+	}
+	return absoluteValue(abs, int64(days))
+}
+
+// DiffInElapsedDays return the difference in elapsed periods of 24 hours
+func (tk *TimeKit) DiffInElapsedDays(t *TimeKit, abs bool) int64 {
 	return tk.DiffInHours(t, abs) / hoursPerDay
 }
 
-// DiffInMonths return the difference in months
+// DiffInMonths return the difference in months , t is read in the location of tk .
+// Unlike the other Diff methods it is positive when t is before tk ,
+// e.g. 1 from 2021-03-15 to 2021-01-20 and -2 from 2021-01-20 to 2021-03-15
 func (tk *TimeKit) DiffInMonths(t *TimeKit, abs bool) int64 {
 	if t == nil {
-		t = createFromTimestamp(time.Now().Unix(), tk.Location())
+		t = createFromTimestamp(now().Unix(), tk.Location())
+	}
+	return countMonthDifference(tk.Time, t.In(tk.Location()), abs)
+}
+
+// DiffInCalendarDays return the number of midnights from tk to t in the location l ,
+// the location of tk if nil , e.g. 1 from 23:00 to 01:00 the next day . It is positive when t is after tk
+func (tk *TimeKit) DiffInCalendarDays(t *TimeKit, l *time.Location, abs bool) int64 {
+	a, b := tk.calendarDates(t, l)
+	return absoluteValue(abs, int64(civilDays(a, b)))
+}
+
+// DiffInCalendarWeeks return the number of starts of week from tk to t in the location l ,
+// the location of tk if nil . The weeks start at the start of week of tk . It is positive when t is after tk
+func (tk *TimeKit) DiffInCalendarWeeks(t *TimeKit, l *time.Location, abs bool) int64 {
+	a, b := tk.calendarDates(t, l)
+	startOfWeek := func(d time.Time) time.Time {
+		return d.AddDate(0, 0, -((int(d.Weekday())-int(tk.weekStartAt))%daysPerWeek+daysPerWeek)%daysPerWeek)
 	}
-	tkCopy := tk.Copy()
-	tCopy := t.Copy()
-	if tkCopy.Location() != tCopy.Location() {
-		tkCopy.Time = tkCopy.In(time.UTC)
-		tCopy.Time = tCopy.In(time.UTC)
+	return absoluteValue(abs, int64(civilDays(startOfWeek(a), startOfWeek(b))/daysPerWeek))
+}
+
+// DiffInCalendarMonths return the number of starts of month from tk to t in the location l ,
+// the location of tk if nil , e.g. 1 from January 31 to February 1 . It is positive when t is after tk ,
+// the opposite of DiffInMonths
+func (tk *TimeKit) DiffInCalendarMonths(t *TimeKit, l *time.Location, abs bool) int64 {
+	a, b := tk.calendarDates(t, l)
+	months := (b.Year()-a.Year())*monthsPerYear + int(b.Month()) - int(a.Month())
+	return absoluteValue(abs, int64(months))
+}
+
+// calendarDates return the dates of tk and t in the location l as dates in UTC
+func (tk *TimeKit) calendarDates(t *TimeKit, l *time.Location) (time.Time, time.Time) {
+	if l == nil {
+		l = tk.Location()
 	}
-	return countMonthDifference(tkCopy.Time, tCopy.Time, abs)
+	if t == nil {
+		t = createFromTimestamp(now().Unix(), l)
+	}
+	a, b := tk.In(l), t.In(l)
+	return time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC),
+		time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
 }
 
 // DiffDurationInString return the duration in string
 func (tk *TimeKit) DiffDurationInString(t *TimeKit) string {
 	if t == nil {
-		t = createFromTimestamp(time.Now().Unix(), tk.Location())
+		t = createFromTimestamp(now().Unix(), tk.Location())
 	}
 	return strings.Replace(tk.Sub(t.Time).String(), "-", "", 1)
 }
@@ -510,7 +576,7 @@ func swap(a, b *TimeKit) (*TimeKit, *TimeKit) {
 //
 func (tk *TimeKit) DiffFiltered(t *TimeKit, duration time.Duration, f Filter, abs bool) int64 {
 	if t == nil {
-		t = createFromTimestamp(time.Now().Unix(), tk.Location())
+		t = createFromTimestamp(now().Unix(), tk.Location())
 	}
 	var diffNumber int64
 
@@ -550,7 +616,7 @@ func (tk *TimeKit) DiffInHoursFiltered(t *TimeKit, f Filter, abs bool) int64 {
 // StartOfCentury return the datetime of start of the century
 func (tk *TimeKit) StartOfCentury() *TimeKit {
 	year := tk.Year() - tk.Year()%yearsPerCenturies
-	t := time.Date(year, time.January, 1, 0, 0, 0, 0, tk.Location())
+	t := tk.date(year, time.January, 1, 0, 0, 0, 0)
 	tk.SetTime(t)
 	return tk
 }
@@ -558,79 +624,81 @@ func (tk *TimeKit) StartOfCentury() *TimeKit {
 // EndOfCentury return the datetime of end of the century
 func (tk *TimeKit) EndOfCentury() *TimeKit {
 	year := tk.Year() - 1 - tk.Year()%yearsPerCenturies + yearsPerCenturies
-	t := time.Date(year, time.December, 31, 23, 59, 59, 0, tk.Location())
+	t := tk.date(year, time.December, 31, 23, 59, 59, 0)
 	tk.SetTime(t)
 	return tk
 }
 
 // StartOfYear return datetime of start of the year
 func (tk *TimeKit) StartOfYear() *TimeKit {
-	tk.SetTime(time.Date(tk.Year(), time.January, 1, 0, 0, 0, 0, tk.Location()))
+	tk.SetTime(tk.date(tk.Year(), time.January, 1, 0, 0, 0, 0))
 	return tk
 }
 
 // EndOfYear return datetime of start of the year
 func (tk *TimeKit) EndOfYear() *TimeKit {
-	tk.SetTime(time.Date(tk.Year(), time.December, 31, 23, 59, 59, 0, tk.Location()))
+	tk.SetTime(tk.date(tk.Year(), time.December, 31, 23, 59, 59, 0))
 	return tk
 }
 
 // StartOfQuarter return datetime of start of the quarter
 func (tk *TimeKit) StartOfQuarter() *TimeKit {
 	m := time.Month((tk.Quarter()-1)*monthsPerQuarter + 1)
-	tk.SetTime(time.Date(tk.Year(), m, 1, 0, 0, 0, 0, tk.Location()))
+	tk.SetTime(tk.date(tk.Year(), m, 1, 0, 0, 0, 0))
 	return tk
 }
 
 // EndOfQuarter return datetime of end of the quarter
 func (tk *TimeKit) EndOfQuarter() *TimeKit {
 	m := tk.Quarter() * monthsPerQuarter
-	tk.SetTime(time.Date(tk.Year(), time.Month(m), 1, 23, 59, 59, 0, tk.Location()).AddDate(0, 1, -1))
+	tk.SetTime(tk.date(tk.Year(), time.Month(m)+1, 0, 23, 59, 59, 0))
 	return tk
 }
 
 // StartOfMonth return datetime of start of the month
 func (tk *TimeKit) StartOfMonth() *TimeKit {
-	tk.SetTime(time.Date(tk.Year(), tk.Month(), 1, 0, 0, 0, 0, tk.Location()))
+	tk.SetTime(tk.date(tk.Year(), tk.Month(), 1, 0, 0, 0, 0))
 	return tk
 }
 
 // EndOfMonth return datetime of end of the month
 func (tk *TimeKit) EndOfMonth() *TimeKit {
-	tk.SetTime(time.Date(tk.Year(), tk.Month(), 1, 0, 0, 0, 0, tk.Location()).AddDate(0, 1, -1))
+	tk.SetTime(tk.date(tk.Year(), tk.Month()+1, 0, 0, 0, 0, 0))
 	return tk
 }
 
 // StartOfWeek return datetime of start of the Week
 func (tk *TimeKit) StartOfWeek() *TimeKit {
-	t := time.Date(tk.Year(), tk.Month(), tk.Day(), 0, 0, 0, 0, tk.Location())
-	for t.Weekday() != tk.weekStartAt {
-		t = t.AddDate(0, 0, -1)
+	day := tk.Day()
+	for wd := tk.Weekday(); wd != tk.weekStartAt; wd = (wd + daysPerWeek - 1) % daysPerWeek {
+		day--
 	}
+	t := tk.date(tk.Year(), tk.Month(), day, 0, 0, 0, 0)
 	tk.SetTime(t)
 	return tk
 }
 
 // EndOfWeek return datetime of end of the Week
 func (tk *TimeKit) EndOfWeek() *TimeKit {
-	t := time.Date(tk.Year(), tk.Month(), tk.Day(), 23, 59, 59, 0, tk.Location())
-	for t.Weekday() != tk.weekEndAt {
-		t = t.AddDate(0, 0, 1)
+	day := tk.Day()
+	for wd := tk.Weekday(); wd != tk.weekEndAt; wd = (wd + 1) % daysPerWeek {
+		day++
 	}
+	t := tk.date(tk.Year(), tk.Month(), day, 23, 59, 59, 0)
 	tk.SetTime(t)
 	return tk
 }
 
 // StartOfDay return datetime of start of the Day
 func (tk *TimeKit) StartOfDay() *TimeKit {
-	t := time.Date(tk.Year(), tk.Month(), tk.Day(), 0, 0, 0, 0, tk.Location())
+	t := tk.date(tk.Year(), tk.Month(), tk.Day(), 0, 0, 0, 0)
 	tk.SetTime(t)
 	return tk
 }
 
 // EndOfDay return datetime of end of the Day
 func (tk *TimeKit) EndOfDay() *TimeKit {
-	t := time.Date(tk.Year(), tk.Month(), tk.Day(), 23, 59, 59, 0, tk.Location())
+	t := tk.date(tk.Year(), tk.Month(), tk.Day(), 23, 59, 59, 0)
 	tk.SetTime(t)
 	return tk
 }
@@ -645,9 +713,21 @@ func (tk *TimeKit) Timestamp() int64 {
 	return tk.Unix()
 }
 
-// Copy return a new TimeKit instance of current instance
+// Copy return a new TimeKit instance of current instance , with its settings
 func (tk *TimeKit) Copy() *TimeKit {
-	return createFromTimestamp(tk.Time.Unix(), tk.Location())
+	tk.lock.Lock()
+	defer tk.lock.Unlock()
+	return &TimeKit{
+		Time:        tk.Time,
+		format:      tk.format,
+		weekendDays: tk.weekendDays,
+		weekStartAt: tk.weekStartAt,
+		weekEndAt:   tk.weekEndAt,
+		wireFormat:  tk.wireFormat,
+		storageMode: tk.storageMode,
+
+		wallTimePolicy: tk.wallTimePolicy,
+	}
 }
 
 // IsWeekend whether the current time is a weekend day
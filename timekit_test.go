@@ -80,6 +80,24 @@ func TestTimeKit_AddMonths(t *testing.T) {
 	}
 }
 
+func TestTimeKit_AddMonthsNoOverflow(t *testing.T) {
+	expected := NewTimeKit(time.Date(2021, 2, 28, 15, 4, 5, 0, l))
+	ntk := NewTimeKit(time.Date(2021, 1, 31, 15, 4, 5, 0, l))
+	ntk.AddMonthsNoOverflow(1)
+
+	if ntk.String() != expected.String() {
+		t.Errorf("AddMonthsNoOverflow = %+v ,expected %+v", ntk, expected)
+	}
+
+	expected = NewTimeKit(time.Date(2020, 11, 30, 15, 4, 5, 0, l))
+	ntk.SetTime(time.Date(2021, 3, 31, 15, 4, 5, 0, l))
+	ntk.SubMonthsNoOverflow(4)
+
+	if ntk.String() != expected.String() {
+		t.Errorf("SubMonthsNoOverflow = %+v ,expected %+v", ntk, expected)
+	}
+}
+
 func TestTimeKit_AddWeek(t *testing.T) {
 	expected := NewTimeKit(time.Date(2021, 1, 16, 15, 4, 5, 0, l))
 	tk.AddWeeks(2)
@@ -176,6 +194,18 @@ func TestTimeKit_StartOfWeek(t *testing.T) {
 	}
 }
 
+func TestOptionSetWeekEndAt(t *testing.T) {
+	date := time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC)
+	ntk := NewOptions(OptionSetTime(date), OptionSetWeekEndAt(time.Friday))
+	if s := ntk.Copy().EndOfWeek().Time.String(); s != "2021-01-08 23:59:59 +0000 UTC" {
+		t.Errorf("EndOfWeek = %s ,expected 2021-01-08 23:59:59 +0000 UTC", s)
+	}
+	// the start of the week is kept
+	if s := ntk.Copy().StartOfWeek().Time.String(); s != "2020-12-28 00:00:00 +0000 UTC" {
+		t.Errorf("StartOfWeek = %s ,expected 2020-12-28 00:00:00 +0000 UTC", s)
+	}
+}
+
 func TestTimeKit_StartOfDay(t *testing.T) {
 	expected := NewTimeKit(time.Date(2021, time.Month(1), 2, 0, 0, 0, 0, l))
 	tk.StartOfDay()
@@ -191,3 +221,113 @@ func TestTimeKit_DiffInMonths(t *testing.T) {
 		t.Errorf("DiffInMonths = %+v ,expected %+v", expected, 3)
 	}
 }
+
+func TestTimeKit_DiffInDays(t *testing.T) {
+	ny, _ := LoadLocation("America/New_York")
+	for _, c := range []struct {
+		a, b     time.Time
+		days     int64
+		elapsed  int64
+		calendar int64
+	}{
+		{time.Date(2021, 3, 13, 12, 0, 0, 0, ny), time.Date(2021, 3, 14, 12, 0, 0, 0, ny), 1, 0, 1},
+		{time.Date(2021, 3, 13, 12, 0, 0, 0, ny), time.Date(2021, 3, 20, 11, 0, 0, 0, ny), 6, 6, 7},
+		{time.Date(2021, 11, 8, 12, 0, 0, 0, ny), time.Date(2021, 11, 6, 12, 0, 0, 0, ny), -2, -2, -2},
+		{time.Date(2021, 11, 8, 12, 0, 0, 0, ny), time.Date(2021, 11, 6, 13, 0, 0, 0, ny), -1, -2, -2},
+		{time.Date(2021, 6, 1, 23, 0, 0, 0, ny), time.Date(2021, 6, 2, 1, 0, 0, 0, ny), 0, 0, 1},
+		// longer than a time.Duration
+		{time.Date(1700, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC), 117243, 117243, 117243},
+	} {
+		a, b := NewTimeKit(c.a), NewTimeKit(c.b)
+		if got := a.DiffInDays(b, false); got != c.days {
+			t.Errorf("DiffInDays(%s, %s) = %+v ,expected %+v", c.a, c.b, got, c.days)
+		}
+		if got := a.DiffInElapsedDays(b, false); got != c.elapsed {
+			t.Errorf("DiffInElapsedDays(%s, %s) = %+v ,expected %+v", c.a, c.b, got, c.elapsed)
+		}
+		if got := a.DiffInCalendarDays(b, nil, false); got != c.calendar {
+			t.Errorf("DiffInCalendarDays(%s, %s) = %+v ,expected %+v", c.a, c.b, got, c.calendar)
+		}
+	}
+
+	// 2021-06-01 23:00 in New York is 2021-06-02 03:00 in UTC and 2021-06-02 12:00 in Tokyo
+	tokyo, _ := LoadLocation("Asia/Tokyo")
+	a := NewTimeKit(time.Date(2021, 6, 1, 23, 0, 0, 0, ny))
+	b := NewTimeKit(time.Date(2021, 6, 3, 1, 0, 0, 0, tokyo))
+	if got := a.DiffInCalendarDays(b, nil, false); got != 1 {
+		t.Errorf("DiffInCalendarDays(New York) = %+v ,expected %+v", got, 1)
+	}
+	if got := a.DiffInCalendarDays(b, tokyo, false); got != 1 {
+		t.Errorf("DiffInCalendarDays(Tokyo) = %+v ,expected %+v", got, 1)
+	}
+	if got := a.DiffInCalendarDays(b, time.UTC, true); got != 0 {
+		t.Errorf("DiffInCalendarDays(UTC) = %+v ,expected %+v", got, 0)
+	}
+}
+
+func TestTimeKit_DiffInCalendarMonths(t *testing.T) {
+	tokyo, _ := LoadLocation("Asia/Tokyo")
+	a := NewTimeKit(time.Date(2021, 1, 31, 12, 0, 0, 0, time.UTC))
+	b := NewTimeKit(time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC))
+	if got := a.DiffInCalendarMonths(b, nil, false); got != 1 {
+		t.Errorf("DiffInCalendarMonths = %+v ,expected %+v", got, 1)
+	}
+	if got := b.DiffInMonths(a, false); got != 0 {
+		t.Errorf("DiffInMonths = %+v ,expected %+v", got, 0)
+	}
+
+	// 2021-03-01 08:00 in Tokyo is still February in UTC
+	c := NewTimeKit(time.Date(2021, 2, 1, 9, 0, 0, 0, tokyo))
+	d := NewTimeKit(time.Date(2021, 3, 1, 8, 0, 0, 0, tokyo))
+	if got := c.DiffInMonths(d, true); got != 1 {
+		t.Errorf("DiffInMonths = %+v ,expected %+v", got, 1)
+	}
+	if got := c.DiffInCalendarMonths(d, time.UTC, false); got != 0 {
+		t.Errorf("DiffInCalendarMonths(UTC) = %+v ,expected %+v", got, 0)
+	}
+	if got := d.DiffInCalendarMonths(a, nil, false); got != -2 {
+		t.Errorf("DiffInCalendarMonths = %+v ,expected %+v", got, -2)
+	}
+
+	// DiffInMonths is positive when t is before tk , DiffInCalendarMonths when t is after tk
+	e := NewTimeKit(time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC))
+	f := NewTimeKit(time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC))
+	for _, c := range []struct {
+		from, to         *TimeKit
+		months, calendar int64
+	}{
+		{e, f, 1, -2},
+		{f, e, -2, 2},
+	} {
+		if got := c.from.DiffInMonths(c.to, false); got != c.months {
+			t.Errorf("DiffInMonths(%s) = %+v ,expected %+v", c.to, got, c.months)
+		}
+		if got := c.from.DiffInCalendarMonths(c.to, nil, false); got != c.calendar {
+			t.Errorf("DiffInCalendarMonths(%s) = %+v ,expected %+v", c.to, got, c.calendar)
+		}
+	}
+}
+
+func TestTimeKit_DiffInCalendarWeeks(t *testing.T) {
+	// 2021-06-06 is a Sunday and 2021-06-07 a Monday
+	a := NewTimeKit(time.Date(2021, 6, 6, 12, 0, 0, 0, time.UTC))
+	b := NewTimeKit(time.Date(2021, 6, 7, 12, 0, 0, 0, time.UTC))
+	if got := a.DiffInCalendarWeeks(b, nil, false); got != 1 {
+		t.Errorf("DiffInCalendarWeeks = %+v ,expected %+v", got, 1)
+	}
+	// 1700-01-01 and 2021-01-01 are Fridays , longer than a time.Duration
+	c := NewTimeKit(time.Date(1700, 1, 1, 12, 0, 0, 0, time.UTC))
+	if got := c.DiffInCalendarWeeks(NewTimeKit(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)), nil, false); got != 16749 {
+		t.Errorf("DiffInCalendarWeeks = %+v ,expected %+v", got, 16749)
+	}
+	if got := b.DiffInCalendarWeeks(a.Copy().AddDays(14), nil, false); got != 1 {
+		t.Errorf("DiffInCalendarWeeks = %+v ,expected %+v", got, 1)
+	}
+	a.SetWeekStartsAt(time.Sunday)
+	if got := a.DiffInCalendarWeeks(b, nil, false); got != 0 {
+		t.Errorf("DiffInCalendarWeeks(Sunday) = %+v ,expected %+v", got, 0)
+	}
+	if got := b.DiffInCalendarWeeks(a, nil, true); got != 1 {
+		t.Errorf("DiffInCalendarWeeks = %+v ,expected %+v", got, 1)
+	}
+}
@@ -0,0 +1,55 @@
+package timkit
+
+import (
+	"time"
+)
+
+// SetTimezone convert tk to the location , keeping the instant and the settings of tk ,
+// e.g. 12:00 UTC is 20:00 in Asia/Shanghai
+func (tk *TimeKit) SetTimezone(location string) (*TimeKit, error) {
+	l, err := loadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	tk.SetTime(tk.In(l))
+	return tk, nil
+}
+
+// ShiftTimezone move tk to the location , keeping the wall time and the settings of tk ,
+// e.g. 12:00 UTC is 12:00 in Asia/Shanghai , 8 hours earlier . A wall time which is skipped
+// or ambiguous in the location is resolved by the wall time policy of tk
+func (tk *TimeKit) ShiftTimezone(location string) (*TimeKit, error) {
+	l, err := loadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	t, err := resolveWallTime(tk.Year(), tk.Month(), tk.Day(), tk.Hour(), tk.Minute(), tk.Second(),
+		tk.Nanosecond(), l, tk.wallTimePolicy)
+	if err != nil {
+		return nil, err
+	}
+	tk.SetTime(t)
+	return tk, nil
+}
+
+// Offset return the offset of tk in seconds east of UTC
+func (tk *TimeKit) Offset() int {
+	_, offset := tk.Zone()
+	return offset
+}
+
+// OffsetString return the offset of tk as `+08:00`
+func (tk *TimeKit) OffsetString() string {
+	return formatOffset(tk.Offset())
+}
+
+// IsUTC whether the offset of tk is zero , e.g. in UTC or in Europe/London during the winter
+func (tk *TimeKit) IsUTC() bool {
+	return tk.Offset() == 0
+}
+
+// IsLocal whether the offset of tk is the offset of the local location at the same instant
+func (tk *TimeKit) IsLocal() bool {
+	_, offset := tk.In(time.Local).Zone()
+	return tk.Offset() == offset
+}
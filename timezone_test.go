@@ -0,0 +1,56 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeKit_SetTimezone(t *testing.T) {
+	tk := NewOptions(OptionSetTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)), OptionSetFormat(time.RFC3339))
+	if _, err := tk.SetTimezone("Asia/Shanghai"); err != nil {
+		t.Fatalf("SetTimezone error = %v", err)
+	}
+	if tk.String() != "2021-06-01T20:00:00+08:00" {
+		t.Errorf("SetTimezone = %s ,expected 2021-06-01T20:00:00+08:00", tk)
+	}
+	if tk.Offset() != 8*3600 || tk.OffsetString() != "+08:00" || tk.IsUTC() {
+		t.Errorf("Offset = %d %s %v ,expected 28800 +08:00 false", tk.Offset(), tk.OffsetString(), tk.IsUTC())
+	}
+	if _, err := tk.SetTimezone("Nowhere/City"); err == nil {
+		t.Errorf("SetTimezone(Nowhere/City) ,expected an error")
+	}
+}
+
+func TestTimeKit_ShiftTimezone(t *testing.T) {
+	tk := NewOptions(OptionSetTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)), OptionSetFormat(time.RFC3339))
+	if _, err := tk.ShiftTimezone("America/New_York"); err != nil {
+		t.Fatalf("ShiftTimezone error = %v", err)
+	}
+	if tk.String() != "2021-06-01T12:00:00-04:00" || tk.OffsetString() != "-04:00" {
+		t.Errorf("ShiftTimezone = %s %s ,expected 2021-06-01T12:00:00-04:00", tk, tk.OffsetString())
+	}
+
+	tk.SetTime(time.Date(2021, 3, 14, 2, 30, 0, 0, time.UTC))
+	tk.SetWallTimePolicy(WallTimeError)
+	if _, err := tk.ShiftTimezone("America/New_York"); err != ErrSkippedWallTime {
+		t.Errorf("ShiftTimezone error = %v ,expected %v", err, ErrSkippedWallTime)
+	}
+	tk.SetWallTimePolicy(WallTimeShiftForward)
+	if _, err := tk.ShiftTimezone("America/New_York"); err != nil || tk.String() != "2021-03-14T03:00:00-04:00" {
+		t.Errorf("ShiftTimezone = %s %v ,expected 2021-03-14T03:00:00-04:00", tk, err)
+	}
+}
+
+func TestTimeKit_IsUTC(t *testing.T) {
+	l, _ := LoadLocation("Europe/London")
+	if !NewTimeKit(time.Date(2021, 1, 1, 0, 0, 0, 0, l)).IsUTC() || NewTimeKit(time.Date(2021, 7, 1, 0, 0, 0, 0, l)).IsUTC() {
+		t.Errorf("IsUTC(Europe/London) ,expected true in the winter and false in the summer")
+	}
+	if !NewTimeKit(time.Now()).IsLocal() {
+		t.Errorf("IsLocal(time.Now) ,expected true")
+	}
+	offset := NewTimeKit(time.Now()).Offset()
+	if NewTimeKit(time.Now().In(time.FixedZone("", offset+3600))).IsLocal() {
+		t.Errorf("IsLocal(%d) ,expected false", offset+3600)
+	}
+}
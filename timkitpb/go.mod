@@ -0,0 +1,12 @@
+module github.com/echotrue/timkit/timkitpb
+
+go 1.17
+
+require (
+	github.com/echotrue/timkit v0.0.0
+	google.golang.org/protobuf v1.26.0
+)
+
+// timkit has no release with LoadLocation yet , the replace is dropped for the tagged version
+// of timkit which contains it , with its go.sum entries
+replace github.com/echotrue/timkit => ../
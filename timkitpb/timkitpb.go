@@ -0,0 +1,90 @@
+// package `timkitpb` convert TimeKit from and to the well known types
+// google.protobuf.Timestamp and google.protobuf.Duration .
+// It is a separate module so `timkit` does not depend on protobuf
+package timkitpb
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/echotrue/timkit"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The range of google.protobuf.Timestamp , from 0001-01-01T00:00:00Z to 9999-12-31T23:59:59.999999999Z
+const (
+	minTimestampSeconds = -62135596800
+	maxTimestampSeconds = 253402300799
+)
+
+var (
+	ErrTimestampRange = errors.New("timkitpb: time out of the range of google.protobuf.Timestamp")
+	ErrDurationRange  = errors.New("timkitpb: duration out of the range of time.Duration")
+)
+
+// ToTimestamp return the timestamp of the instant of tk , the location is not kept .
+// A nil TimeKit give a nil timestamp
+func ToTimestamp(tk *timkit.TimeKit) (*timestamppb.Timestamp, error) {
+	if tk == nil {
+		return nil, nil
+	}
+	sec := tk.Unix()
+	if sec < minTimestampSeconds || sec > maxTimestampSeconds {
+		return nil, ErrTimestampRange
+	}
+	return &timestamppb.Timestamp{Seconds: sec, Nanos: int32(tk.Nanosecond())}, nil
+}
+
+// FromTimestamp return a TimeKit of the timestamp in the given location .
+// A nil timestamp give a nil TimeKit
+func FromTimestamp(ts *timestamppb.Timestamp, location string) (*timkit.TimeKit, error) {
+	l, err := timkit.LoadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, err
+	}
+	return timkit.NewTimeKit(ts.AsTime().In(l)), nil
+}
+
+// ToDuration return the duration as a google.protobuf.Duration , every time.Duration is in its range
+func ToDuration(d time.Duration) *durationpb.Duration {
+	return durationpb.New(d)
+}
+
+// FromDuration return the time.Duration of a google.protobuf.Duration .
+// Unlike AsDuration it return an error instead of saturating when the duration overflow
+func FromDuration(d *durationpb.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+	if err := d.CheckValid(); err != nil {
+		return 0, err
+	}
+	sec, nsec := d.GetSeconds(), int64(d.GetNanos())
+	if sec > math.MaxInt64/int64(time.Second) || sec < math.MinInt64/int64(time.Second) {
+		return 0, ErrDurationRange
+	}
+	total := sec * int64(time.Second)
+	if nsec > 0 && total > math.MaxInt64-nsec || nsec < 0 && total < math.MinInt64-nsec {
+		return 0, ErrDurationRange
+	}
+	return time.Duration(total + nsec), nil
+}
+
+// AddDuration return a copy of tk moved by the duration , tk is not modified
+func AddDuration(tk *timkit.TimeKit, d *durationpb.Duration) (*timkit.TimeKit, error) {
+	dur, err := FromDuration(d)
+	if err != nil {
+		return nil, err
+	}
+	c := tk.Copy()
+	c.SetTime(c.Add(dur))
+	return c, nil
+}
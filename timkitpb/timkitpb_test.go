@@ -0,0 +1,74 @@
+package timkitpb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/echotrue/timkit"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTimestamp(t *testing.T) {
+	l, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+	tk := timkit.NewTimeKit(time.Date(2021, 1, 2, 15, 4, 5, 123456789, l))
+	ts, err := ToTimestamp(tk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts.Seconds != 1609571045 || ts.Nanos != 123456789 {
+		t.Errorf("ToTimestamp = %+v ,expected %+v", ts, "1609571045.123456789")
+	}
+
+	back, err := FromTimestamp(ts, "Asia/Shanghai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !back.Equal(tk.Time) || back.Location().String() != "Asia/Shanghai" || back.Nanosecond() != 123456789 {
+		t.Errorf("FromTimestamp = %+v ,expected %+v", back.Time, tk.Time)
+	}
+
+	if ts, err := ToTimestamp(nil); ts != nil || err != nil {
+		t.Errorf("ToTimestamp(nil) = %+v %v ,expected nil", ts, err)
+	}
+	if tk, err := FromTimestamp(nil, "UTC"); tk != nil || err != nil {
+		t.Errorf("FromTimestamp(nil) = %+v %v ,expected nil", tk, err)
+	}
+	if _, err := ToTimestamp(timkit.NewTimeKit(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))); err != ErrTimestampRange {
+		t.Errorf("ToTimestamp error = %v ,expected %v", err, ErrTimestampRange)
+	}
+	if _, err := FromTimestamp(&timestamppb.Timestamp{Seconds: maxTimestampSeconds + 1}, "UTC"); err == nil {
+		t.Errorf("FromTimestamp ,expected an error")
+	}
+	if _, err := FromTimestamp(ts, "Nowhere/City"); err == nil {
+		t.Errorf("FromTimestamp ,expected an error")
+	}
+}
+
+func TestDuration(t *testing.T) {
+	for _, d := range []time.Duration{0, -1500 * time.Millisecond, 36*time.Hour + 1, math.MaxInt64, math.MinInt64} {
+		back, err := FromDuration(ToDuration(d))
+		if err != nil || back != d {
+			t.Errorf("FromDuration(ToDuration(%v)) = %v %v ,expected %v", int64(d), int64(back), err, int64(d))
+		}
+	}
+	if _, err := FromDuration(&durationpb.Duration{Seconds: 315576000000}); err != ErrDurationRange {
+		t.Errorf("FromDuration error = %v ,expected %v", err, ErrDurationRange)
+	}
+	if _, err := FromDuration(&durationpb.Duration{Seconds: 1, Nanos: -1}); err == nil {
+		t.Errorf("FromDuration ,expected an error")
+	}
+
+	tk := timkit.NewTimeKit(time.Date(2021, 1, 2, 15, 4, 5, 0, time.UTC))
+	moved, err := AddDuration(tk, ToDuration(-90*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.String() != "2021-01-02 13:34:05" || tk.String() != "2021-01-02 15:04:05" {
+		t.Errorf("AddDuration = %+v ,expected %+v", moved, "2021-01-02 13:34:05")
+	}
+}
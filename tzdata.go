@@ -0,0 +1,8 @@
+//go:build timkit_tzdata
+// +build timkit_tzdata
+
+package timkit
+
+// Building with the tag `timkit_tzdata` embed a copy of the time zone database (about 450 KB) ,
+// used by SystemLocationProvider when the system has none
+import _ "time/tzdata"
@@ -0,0 +1,80 @@
+package timkit
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// tzifZone is a local time type of a TZif file , the offset is in seconds east of UTC
+type tzifZone struct {
+	offset int
+	isDST  bool
+	name   string
+}
+
+// tzifTransition is the unix time from which a zone is used
+type tzifTransition struct {
+	when int64
+	zone int
+}
+
+// encodeTZif return a TZif version 2 file (RFC 8536) , as read by time.LoadLocationFromTZData .
+// The footer is a POSIX TZ string used after the last transition , or for all times without one
+func encodeTZif(zones []tzifZone, transitions []tzifTransition, footer string) []byte {
+	var chars []byte
+	index := make(map[string]int)
+	for _, z := range zones {
+		if _, ok := index[z.name]; !ok {
+			index[z.name] = len(chars)
+			chars = append(append(chars, z.name...), 0)
+		}
+	}
+
+	// the version 1 block only has the transitions fitting in 32 bits
+	var short []tzifTransition
+	for _, t := range transitions {
+		if t.when >= math.MinInt32 && t.when <= math.MaxInt32 {
+			short = append(short, t)
+		}
+	}
+
+	data := appendTZifBlock(nil, zones, short, chars, index, false)
+	data = appendTZifBlock(data, zones, transitions, chars, index, true)
+	return append(append(append(data, '\n'), footer...), '\n')
+}
+
+func appendTZifBlock(data []byte, zones []tzifZone, transitions []tzifTransition,
+	chars []byte, index map[string]int, is64 bool) []byte {
+	data = append(data, "TZif2"...)
+	data = append(data, make([]byte, 15)...)
+	for _, n := range []int{0, 0, 0, len(transitions), len(zones), len(chars)} {
+		data = appendUint32(data, uint32(n))
+	}
+	for _, t := range transitions {
+		if is64 {
+			var b [8]byte
+			binary.BigEndian.PutUint64(b[:], uint64(t.when))
+			data = append(data, b[:]...)
+		} else {
+			data = appendUint32(data, uint32(int32(t.when)))
+		}
+	}
+	for _, t := range transitions {
+		data = append(data, byte(t.zone))
+	}
+	for _, z := range zones {
+		data = appendUint32(data, uint32(int32(z.offset)))
+		isDST := byte(0)
+		if z.isDST {
+			isDST = 1
+		}
+		data = append(data, isDST, byte(index[z.name]))
+	}
+	return append(data, chars...)
+}
+
+func appendUint32(data []byte, v uint32) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	return append(data, b[:]...)
+}
@@ -0,0 +1,168 @@
+package timkit
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Unit is a calendar unit used to align and round times
+type Unit int
+
+const (
+	UnitSecond Unit = iota + 1
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitQuarter
+	UnitYear
+)
+
+var ErrInvalidUnit = errors.New("timkit: invalid unit")
+
+var unitNames = map[Unit]string{
+	UnitSecond:  "second",
+	UnitMinute:  "minute",
+	UnitHour:    "hour",
+	UnitDay:     "day",
+	UnitWeek:    "week",
+	UnitMonth:   "month",
+	UnitQuarter: "quarter",
+	UnitYear:    "year",
+}
+
+// String return the name of the unit
+func (u Unit) String() string {
+	if name, ok := unitNames[u]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseUnit return the unit of a name such as "day" or "days" , case insensitive
+func ParseUnit(name string) (Unit, error) {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), "s")
+	for u, n := range unitNames {
+		if n == name {
+			return u, nil
+		}
+	}
+	return 0, ErrInvalidUnit
+}
+
+// valid whether the unit is one of the defined units
+func (u Unit) valid() bool {
+	_, ok := unitNames[u]
+	return ok
+}
+
+// StartOfMinute return datetime of start of the minute
+func (tk *TimeKit) StartOfMinute() *TimeKit {
+	d := time.Duration(tk.Second())*time.Second + time.Duration(tk.Nanosecond())
+	tk.SetTime(tk.Add(-d))
+	return tk
+}
+
+// EndOfMinute return datetime of end of the minute
+func (tk *TimeKit) EndOfMinute() *TimeKit {
+	tk.StartOfMinute()
+	tk.SetTime(tk.Add(time.Minute - time.Second))
+	return tk
+}
+
+// StartOfHour return datetime of start of the hour
+func (tk *TimeKit) StartOfHour() *TimeKit {
+	d := time.Duration(tk.Minute())*time.Minute + time.Duration(tk.Second())*time.Second + time.Duration(tk.Nanosecond())
+	tk.SetTime(tk.Add(-d))
+	return tk
+}
+
+// EndOfHour return datetime of end of the hour
+func (tk *TimeKit) EndOfHour() *TimeKit {
+	tk.StartOfHour()
+	tk.SetTime(tk.Add(time.Hour - time.Second))
+	return tk
+}
+
+// StartOf return datetime of start of the given unit
+func (tk *TimeKit) StartOf(u Unit) *TimeKit {
+	switch u {
+	case UnitSecond:
+		tk.SetTime(tk.Add(-time.Duration(tk.Nanosecond())))
+	case UnitMinute:
+		tk.StartOfMinute()
+	case UnitHour:
+		tk.StartOfHour()
+	case UnitDay:
+		tk.StartOfDay()
+	case UnitWeek:
+		tk.StartOfWeek()
+	case UnitMonth:
+		tk.StartOfMonth()
+	case UnitQuarter:
+		tk.StartOfQuarter()
+	case UnitYear:
+		tk.StartOfYear()
+	}
+	return tk
+}
+
+// EndOf return datetime of end of the given unit
+func (tk *TimeKit) EndOf(u Unit) *TimeKit {
+	switch u {
+	case UnitSecond:
+		tk.SetTime(tk.Add(-time.Duration(tk.Nanosecond())))
+	case UnitMinute:
+		tk.EndOfMinute()
+	case UnitHour:
+		tk.EndOfHour()
+	case UnitDay:
+		tk.EndOfDay()
+	case UnitWeek:
+		tk.EndOfWeek()
+	case UnitMonth:
+		tk.EndOfMonth()
+	case UnitQuarter:
+		tk.EndOfQuarter()
+	case UnitYear:
+		tk.EndOfYear()
+	}
+	return tk
+}
+
+// addUnits add n units to a start of unit , keeping it aligned on the local calendar . The days
+// start at their first instant , which is after midnight when the midnight is skipped
+func addUnits(t time.Time, u Unit, n int) time.Time {
+	switch u {
+	case UnitSecond:
+		return t.Add(time.Duration(n) * time.Second)
+	case UnitMinute:
+		return t.Add(time.Duration(n) * time.Minute)
+	case UnitHour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case UnitDay:
+		return startOfDate(t.Year(), t.Month(), t.Day()+n, t.Location())
+	case UnitWeek:
+		return startOfDate(t.Year(), t.Month(), t.Day()+n*daysPerWeek, t.Location())
+	case UnitMonth:
+		return startOfDate(t.Year(), t.Month()+time.Month(n), 1, t.Location())
+	case UnitQuarter:
+		return startOfDate(t.Year(), t.Month()+time.Month(n*monthsPerQuarter), 1, t.Location())
+	case UnitYear:
+		return startOfDate(t.Year()+n, time.January, 1, t.Location())
+	}
+	return t
+}
+
+// nextBoundary return the first start of unit strictly after tk , tk is not modified
+func (tk *TimeKit) nextBoundary(u Unit) *TimeKit {
+	b := tk.Copy().StartOf(u)
+	t := b.Time
+	for !t.After(tk.Time) {
+		t = addUnits(t, u, 1)
+	}
+	b.SetTime(t)
+	return b
+}
@@ -0,0 +1,198 @@
+package timkit
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSkippedWallTime   = errors.New("timkit: the wall time is skipped in the location")
+	ErrAmbiguousWallTime = errors.New("timkit: the wall time is ambiguous in the location")
+)
+
+// WallTimePolicy decide the instant of a wall time which is skipped when the clock moves forward ,
+// e.g. 02:30 when the daylight saving time begins , or repeated when the clock moves back
+type WallTimePolicy int
+
+const (
+	// WallTimeNormalize is the normalization of time.Date , which does not specify the instant
+	WallTimeNormalize WallTimePolicy = iota
+	// WallTimeEarlier choose the earlier instant , a skipped wall time is moved back by the gap
+	WallTimeEarlier
+	// WallTimeLater choose the later instant , a skipped wall time is moved forward by the gap
+	WallTimeLater
+	// WallTimeShiftForward choose the earlier instant , a skipped wall time is moved to the end of the gap
+	WallTimeShiftForward
+	// WallTimeError return ErrSkippedWallTime or ErrAmbiguousWallTime ,
+	// the methods without an error such as StartOfDay normalize as WallTimeNormalize
+	WallTimeError
+)
+
+// WallTimeStatus is whether a wall time exists once , never or twice in a location
+type WallTimeStatus int
+
+const (
+	WallTimeValid WallTimeStatus = iota
+	WallTimeSkipped
+	WallTimeAmbiguous
+)
+
+// String return valid , skipped or ambiguous
+func (s WallTimeStatus) String() string {
+	switch s {
+	case WallTimeSkipped:
+		return "skipped"
+	case WallTimeAmbiguous:
+		return "ambiguous"
+	}
+	return "valid"
+}
+
+// wallTimeWindow is how far from a wall time the offsets around it are read
+const wallTimeWindow = hoursPerDay * minutesPerHour * secondsPerMinute
+
+// wallClockLocation is given to time.ParseInLocation to find if a value has no zone ,
+// no value has an offset of one second
+var wallClockLocation = time.FixedZone("timkit-wall-clock", 1)
+
+// OptionSetWallTimePolicy set the policy of the skipped and ambiguous wall times
+func OptionSetWallTimePolicy(p WallTimePolicy) Option {
+	return func(t *TimeKit) {
+		t.wallTimePolicy = p
+	}
+}
+
+// SetWallTimePolicy set the policy of the skipped and ambiguous wall times
+func (tk *TimeKit) SetWallTimePolicy(p WallTimePolicy) {
+	tk.lock.Lock()
+	defer tk.lock.Unlock()
+	tk.wallTimePolicy = p
+}
+
+// WallTimePolicy return the policy of the skipped and ambiguous wall times
+func (tk *TimeKit) WallTimePolicy() WallTimePolicy {
+	return tk.wallTimePolicy
+}
+
+// Create return a new TimeKit instance of a wall time in the given location ,
+// resolved by the policy set by OptionSetWallTimePolicy
+func Create(year int, month time.Month, day, hour, min, sec, nsec int, location string, opt ...Option) (*TimeKit, error) {
+	l, err := loadLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	tk := NewOptions(opt...)
+	t, err := resolveWallTime(year, month, day, hour, min, sec, nsec, l, tk.wallTimePolicy)
+	if err != nil {
+		return nil, err
+	}
+	tk.SetTime(t)
+	return tk, nil
+}
+
+// SetDateTime set the wall time in the location of tk , resolved by the policy of tk
+func (tk *TimeKit) SetDateTime(year int, month time.Month, day, hour, min, sec, nsec int) error {
+	t, err := resolveWallTime(year, month, day, hour, min, sec, nsec, tk.Location(), tk.wallTimePolicy)
+	if err != nil {
+		return err
+	}
+	tk.SetTime(t)
+	return nil
+}
+
+// CheckWallTime return whether a wall time is valid , skipped or ambiguous in the location
+func CheckWallTime(year int, month time.Month, day, hour, min, sec int, location string) (WallTimeStatus, error) {
+	l, err := loadLocation(location)
+	if err != nil {
+		return WallTimeValid, err
+	}
+	status, _ := wallTimeInstants(time.Date(year, month, day, hour, min, sec, 0, time.UTC).Unix(), l)
+	return status, nil
+}
+
+// startOfDate return the first instant of a local date , the end of the gap when its midnight is skipped
+func startOfDate(year int, month time.Month, day int, l *time.Location) time.Time {
+	t, _ := resolveWallTime(year, month, day, 0, 0, 0, 0, l, WallTimeShiftForward)
+	return t
+}
+
+// date is time.Date in the location of tk resolved by its policy , without error
+func (tk *TimeKit) date(year int, month time.Month, day, hour, min, sec, nsec int) time.Time {
+	t, err := resolveWallTime(year, month, day, hour, min, sec, nsec, tk.Location(), tk.wallTimePolicy)
+	if err != nil {
+		return time.Date(year, month, day, hour, min, sec, nsec, tk.Location())
+	}
+	return t
+}
+
+// parseWallTime resolve by the policy a time parsed from a value without zone
+func parseWallTime(layout, value string, t time.Time, p WallTimePolicy) (time.Time, error) {
+	if p == WallTimeNormalize {
+		return t, nil
+	}
+	w, err := time.ParseInLocation(layout, value, wallClockLocation)
+	if err != nil || w.Location() != wallClockLocation {
+		return t, nil
+	}
+	return resolveWallTime(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), t.Location(), p)
+}
+
+func resolveWallTime(year int, month time.Month, day, hour, min, sec, nsec int, l *time.Location,
+	p WallTimePolicy) (time.Time, error) {
+	if p == WallTimeNormalize {
+		return time.Date(year, month, day, hour, min, sec, nsec, l), nil
+	}
+	wall := time.Date(year, month, day, hour, min, sec, nsec, time.UTC)
+	status, instants := wallTimeInstants(wall.Unix(), l)
+	if len(instants) == 0 {
+		return time.Date(year, month, day, hour, min, sec, nsec, l), nil
+	}
+
+	u := instants[0]
+	switch {
+	case status == WallTimeValid:
+	case p == WallTimeError && status == WallTimeSkipped:
+		return time.Time{}, ErrSkippedWallTime
+	case p == WallTimeError:
+		return time.Time{}, ErrAmbiguousWallTime
+	case p == WallTimeLater:
+		u = instants[1]
+	case p == WallTimeShiftForward && status == WallTimeSkipped:
+		return time.Unix(searchTransition(l, instants[0], instants[1]), 0).In(l), nil
+	}
+	return time.Unix(u, int64(wall.Nanosecond())).In(l), nil
+}
+
+// wallTimeInstants return the status of a wall time , given in seconds as if it were UTC , and
+// its instants : the only one when it is valid , else the earlier and the later instant read
+// with the offsets before and after it . No instant is returned when the offsets around the wall
+// time are not understood , e.g. two transitions within wallTimeWindow
+func wallTimeInstants(wall int64, l *time.Location) (WallTimeStatus, []int64) {
+	_, before := zoneAt(l, wall-wallTimeWindow)
+	_, after := zoneAt(l, wall+wallTimeWindow)
+	var valid []int64
+	for i, offset := range []int{before, after} {
+		if i == 1 && after == before {
+			break
+		}
+		u := wall - int64(offset)
+		if _, o := zoneAt(l, u); o == offset {
+			valid = append(valid, u)
+		}
+	}
+
+	switch {
+	case len(valid) == 1:
+		return WallTimeValid, valid
+	case before == after:
+		return WallTimeValid, nil
+	}
+	instants := []int64{wall - int64(before), wall - int64(after)}
+	if instants[0] > instants[1] {
+		instants[0], instants[1] = instants[1], instants[0]
+	}
+	if len(valid) == 0 {
+		return WallTimeSkipped, instants
+	}
+	return WallTimeAmbiguous, instants
+}
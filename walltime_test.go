@@ -0,0 +1,123 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckWallTime(t *testing.T) {
+	for _, c := range []struct {
+		location string
+		y, m, d  int
+		h, mi    int
+		expected WallTimeStatus
+	}{
+		{"America/New_York", 2021, 3, 14, 2, 30, WallTimeSkipped},
+		{"America/New_York", 2021, 3, 14, 3, 0, WallTimeValid},
+		{"America/New_York", 2021, 11, 7, 1, 30, WallTimeAmbiguous},
+		{"America/New_York", 2021, 11, 7, 2, 0, WallTimeValid},
+		{"Europe/Berlin", 2021, 3, 28, 2, 0, WallTimeSkipped},
+		{"Europe/Berlin", 2021, 10, 31, 2, 59, WallTimeAmbiguous},
+		{"Pacific/Apia", 2011, 12, 30, 12, 0, WallTimeSkipped},
+		{"Asia/Shanghai", 2021, 3, 14, 2, 30, WallTimeValid},
+	} {
+		status, err := CheckWallTime(c.y, time.Month(c.m), c.d, c.h, c.mi, 0, c.location)
+		if err != nil || status != c.expected {
+			t.Errorf("CheckWallTime(%s %d-%d-%d %d:%d) = %s %v ,expected %s",
+				c.location, c.y, c.m, c.d, c.h, c.mi, status, err, c.expected)
+		}
+	}
+	if _, err := CheckWallTime(2021, 1, 1, 0, 0, 0, "Nowhere/City"); err == nil {
+		t.Errorf("CheckWallTime(Nowhere/City) ,expected an error")
+	}
+}
+
+func TestCreate(t *testing.T) {
+	for _, c := range []struct {
+		policy   WallTimePolicy
+		h        int
+		expected string
+		err      error
+	}{
+		{WallTimeEarlier, 2, "2021-03-14 01:30:00 -0500 EST", nil},
+		{WallTimeLater, 2, "2021-03-14 03:30:00 -0400 EDT", nil},
+		{WallTimeShiftForward, 2, "2021-03-14 03:00:00 -0400 EDT", nil},
+		{WallTimeError, 2, "", ErrSkippedWallTime},
+		{WallTimeEarlier, 1, "2021-11-07 01:30:00 -0400 EDT", nil},
+		{WallTimeLater, 1, "2021-11-07 01:30:00 -0500 EST", nil},
+		{WallTimeShiftForward, 1, "2021-11-07 01:30:00 -0400 EDT", nil},
+		{WallTimeError, 1, "", ErrAmbiguousWallTime},
+		{WallTimeError, 12, "2021-11-07 12:30:00 -0500 EST", nil},
+	} {
+		day := 14
+		month := time.March
+		if c.h != 2 {
+			day, month = 7, time.November
+		}
+		tk, err := Create(2021, month, day, c.h, 30, 0, 0, "America/New_York", OptionSetWallTimePolicy(c.policy))
+		if err != c.err {
+			t.Errorf("Create(%d) error = %v ,expected %v", c.policy, err, c.err)
+			continue
+		}
+		if err == nil && tk.Time.String() != c.expected {
+			t.Errorf("Create(%d) = %s ,expected %s", c.policy, tk.Time, c.expected)
+		}
+		if err == nil && tk.WallTimePolicy() != c.policy {
+			t.Errorf("WallTimePolicy = %+v ,expected %+v", tk.WallTimePolicy(), c.policy)
+		}
+	}
+}
+
+func TestTimeKit_SetDateTime(t *testing.T) {
+	l, _ := LoadLocation("Europe/Berlin")
+	tk := NewOptions(OptionSetTime(time.Date(2021, 1, 1, 0, 0, 0, 0, l)), OptionSetWallTimePolicy(WallTimeLater))
+	if err := tk.SetDateTime(2021, time.October, 31, 2, 30, 0, 0); err != nil || tk.Time.String() != "2021-10-31 02:30:00 +0100 CET" {
+		t.Errorf("SetDateTime = %s %v ,expected 2021-10-31 02:30:00 +0100 CET", tk.Time, err)
+	}
+	tk.SetWallTimePolicy(WallTimeError)
+	if err := tk.SetDateTime(2021, time.March, 28, 2, 30, 0, 0); err != ErrSkippedWallTime {
+		t.Errorf("SetDateTime error = %v ,expected %v", err, ErrSkippedWallTime)
+	}
+	if tk.Time.String() != "2021-10-31 02:30:00 +0100 CET" {
+		t.Errorf("SetDateTime changed the time to %s on error", tk.Time)
+	}
+}
+
+func TestParse_WallTimePolicy(t *testing.T) {
+	tk, err := Parse(DefaultFormat, "2021-11-07 01:30:00", "America/New_York", OptionSetWallTimePolicy(WallTimeLater))
+	if err != nil || tk.Time.String() != "2021-11-07 01:30:00 -0500 EST" {
+		t.Errorf("Parse = %s %v ,expected 2021-11-07 01:30:00 -0500 EST", tk.Time, err)
+	}
+	if _, err := Parse(DefaultFormat, "2021-03-14 02:30:00", "America/New_York", OptionSetWallTimePolicy(WallTimeError)); err != ErrSkippedWallTime {
+		t.Errorf("Parse error = %v ,expected %v", err, ErrSkippedWallTime)
+	}
+
+	// a value with an offset is not resolved
+	tk, err = Parse(time.RFC3339, "2021-11-07T01:30:00-04:00", "America/New_York", OptionSetWallTimePolicy(WallTimeLater))
+	if err != nil || tk.Time.String() != "2021-11-07 01:30:00 -0400 EDT" {
+		t.Errorf("Parse = %s %v ,expected 2021-11-07 01:30:00 -0400 EDT", tk.Time, err)
+	}
+	tk, err = Parse(DefaultFormat, "2021-11-07 01:30:00", "America/New_York", OptionSetFormat(DateFormat))
+	if err != nil || tk.format != DateFormat {
+		t.Errorf("Parse format = %s %v ,expected %s", tk.format, err, DateFormat)
+	}
+}
+
+func TestTimeKit_StartOfDay_WallTimePolicy(t *testing.T) {
+	// the clock moved from 00:00 to 01:00 in Havana on 2021-03-14
+	l, _ := LoadLocation("America/Havana")
+	tk := NewOptions(OptionSetTime(time.Date(2021, 3, 14, 12, 0, 0, 0, l)), OptionSetWallTimePolicy(WallTimeShiftForward))
+	if tk.StartOfDay(); tk.Time.String() != "2021-03-14 01:00:00 -0400 CDT" {
+		t.Errorf("StartOfDay = %s ,expected 2021-03-14 01:00:00 -0400 CDT", tk.Time)
+	}
+	tk.SetTime(time.Date(2021, 3, 14, 12, 0, 0, 0, l))
+	tk.SetWallTimePolicy(WallTimeEarlier)
+	if tk.StartOfDay(); tk.Time.String() != "2021-03-13 23:00:00 -0500 CST" {
+		t.Errorf("StartOfDay = %s ,expected 2021-03-13 23:00:00 -0500 CST", tk.Time)
+	}
+
+	tk.SetTime(time.Date(2021, 6, 16, 12, 0, 0, 0, l))
+	if tk.Copy().StartOfWeek().DateString() != "2021-06-14" || tk.Copy().EndOfWeek().DateTimeString() != "2021-06-20 23:59:59" {
+		t.Errorf("StartOfWeek EndOfWeek = %s %s ,expected 2021-06-14 2021-06-20", tk.Copy().StartOfWeek(), tk.Copy().EndOfWeek())
+	}
+}
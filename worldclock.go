@@ -0,0 +1,77 @@
+package timkit
+
+import (
+	"fmt"
+	"time"
+)
+
+// ZoneTime is an instant seen from one location
+type ZoneTime struct {
+	Location *time.Location
+	Time     *TimeKit
+	// Offset is the offset east of UTC in seconds
+	Offset int
+	// DayChange is the number of days the local date is ahead of the reference date , e.g. -1 or +1
+	DayChange int
+}
+
+// OffsetString return the offset as `+08:00`
+func (z ZoneTime) OffsetString() string {
+	return formatOffset(z.Offset)
+}
+
+// formatOffset format an offset in seconds as `+08:00`
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset/60%60)
+}
+
+// WorldClock return tk seen from each of the given locations .
+// The day change is relative to the local date of tk
+func WorldClock(tk *TimeKit, locations ...string) ([]ZoneTime, error) {
+	zones := make([]ZoneTime, 0, len(locations))
+	for _, name := range locations {
+		l, err := loadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+		local := tk.Copy()
+		local.SetTime(tk.In(l))
+		_, offset := local.Zone()
+		zones = append(zones, ZoneTime{
+			Location:  l,
+			Time:      local,
+			Offset:    offset,
+			DayChange: civilDays(tk.Time, local.Time),
+		})
+	}
+	return zones, nil
+}
+
+// ZoneHours is the working hours of a location
+type ZoneHours struct {
+	Location     string
+	WorkingHours WorkingHours
+	Holidays     HolidayCalendar
+}
+
+// WorkingOverlap return the periods of [from, to) inside the working hours of every location .
+// The working hours follow the local clock of each location , so the overlap moves with DST
+func WorkingOverlap(from, to *TimeKit, zones ...ZoneHours) ([]Period, error) {
+	free := []span{{from.Time, to.Time}}
+	for _, z := range zones {
+		l, err := loadLocation(z.Location)
+		if err != nil {
+			return nil, err
+		}
+		free = intersectSpans(free, mergeSpans(z.WorkingHours.spans(l, from.Time, to.Time, z.Holidays)))
+	}
+	periods := make([]Period, 0, len(free))
+	for _, f := range free {
+		periods = append(periods, f.period(from.Location()))
+	}
+	return periods, nil
+}
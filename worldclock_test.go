@@ -0,0 +1,70 @@
+package timkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorldClock(t *testing.T) {
+	tk, err := Parse(DefaultFormat, "2021-01-02 23:30:00", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zones, err := WorldClock(tk, "Asia/Shanghai", "America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		time      string
+		offset    string
+		dayChange int
+	}{
+		{"2021-01-03 07:30:00", "+08:00", 1},
+		{"2021-01-02 18:30:00", "-05:00", 0},
+	}
+	for i, z := range zones {
+		if z.Time.String() != expected[i].time || z.OffsetString() != expected[i].offset || z.DayChange != expected[i].dayChange {
+			t.Errorf("WorldClock = %+v %+v %+v ,expected %+v", z.Time, z.OffsetString(), z.DayChange, expected[i])
+		}
+	}
+}
+
+func TestWorkingOverlap(t *testing.T) {
+	from, _ := Parse(DefaultFormat, "2021-03-12 00:00:00", "UTC")
+	to, _ := Parse(DefaultFormat, "2021-03-16 00:00:00", "UTC")
+	hours := WorkingHours{Start: 9 * time.Hour, End: 17 * time.Hour}
+
+	overlap, err := WorkingOverlap(from, to,
+		ZoneHours{Location: "Europe/London", WorkingHours: hours},
+		ZoneHours{Location: "America/New_York", WorkingHours: hours},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// New York moved to DST on 2021-03-14 , London on 2021-03-28
+	expected := []string{
+		"2021-03-12 14:00:00 - 2021-03-12 17:00:00",
+		"2021-03-15 13:00:00 - 2021-03-15 17:00:00",
+	}
+	if got := periodStrings(overlap); !equalStrings(got, expected) {
+		t.Errorf("WorkingOverlap = %+v ,expected %+v", got, expected)
+	}
+	// Santiago moved to DST on 2021-09-05 , its midnight was skipped
+	from, _ = Parse(DefaultFormat, "2021-09-03 00:00:00", "UTC")
+	to, _ = Parse(DefaultFormat, "2021-09-07 00:00:00", "UTC")
+	overlap, err = WorkingOverlap(from, to,
+		ZoneHours{Location: "America/Santiago", WorkingHours: hours},
+		ZoneHours{Location: "America/New_York", WorkingHours: hours},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{
+		"2021-09-03 13:00:00 - 2021-09-03 21:00:00",
+		"2021-09-06 13:00:00 - 2021-09-06 20:00:00",
+	}
+	if got := periodStrings(overlap); !equalStrings(got, expected) {
+		t.Errorf("WorkingOverlap = %+v ,expected %+v", got, expected)
+	}
+}